@@ -0,0 +1,80 @@
+package shares
+
+import (
+	"fmt"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+)
+
+// ReconstructBlobFromPartial returns the data of the blob that starts at flat
+// index blobStartIndex of a data square of width squareSize and occupies
+// totalShares shares. available contains the shares of the original data
+// square that are known, keyed by flat index, and may include shares that
+// don't belong to the blob. rowParity contains the known parity shares of each
+// row in the top half of the extended data square, keyed by row index, with a
+// nil entry for each missing parity share. Missing shares of the blob are
+// recovered by Reed-Solomon decoding their row so it returns an error if fewer
+// than squareSize shares of a row with a missing blob share are known.
+func ReconstructBlobFromPartial(available map[int]*Share, blobStartIndex, totalShares int, rowParity map[int][]*Share, squareSize int) ([]byte, error) {
+	if err := ValidateSquareSize(squareSize); err != nil {
+		return nil, err
+	}
+	if totalShares <= 0 {
+		return nil, fmt.Errorf("total shares %d must be positive", totalShares)
+	}
+	blobEndIndex := blobStartIndex + totalShares - 1
+	if !isValidFlatIndex(blobStartIndex, squareSize) || !isValidFlatIndex(blobEndIndex, squareSize) {
+		return nil, fmt.Errorf("blob with start index %d and share count %d does not fit in a square of size %d", blobStartIndex, totalShares, squareSize)
+	}
+
+	codec := appconsts.DefaultCodec()
+	// recoveredRows caches the decoded original shares of each row so that a
+	// row is decoded at most once
+	recoveredRows := make(map[int][][]byte)
+	blobShares := make([]Share, 0, totalShares)
+	for i := blobStartIndex; i <= blobEndIndex; i++ {
+		if share, ok := available[i]; ok && share != nil {
+			blobShares = append(blobShares, *share)
+			continue
+		}
+
+		row, col := rowCol(i, squareSize)
+		original, ok := recoveredRows[row]
+		if !ok {
+			extendedRow, err := partialExtendedRow(available, rowParity, row, squareSize)
+			if err != nil {
+				return nil, err
+			}
+			original, err = decodeRow(codec, extendedRow, squareSize)
+			if err != nil {
+				return nil, fmt.Errorf("failed to recover share %d from row %d: %w", i, row, err)
+			}
+			recoveredRows[row] = original
+		}
+		blobShares = append(blobShares, Share{data: original[col]})
+	}
+
+	if err := validateSingleSequence(blobShares); err != nil {
+		return nil, err
+	}
+	return ShareSequence{NamespaceID: blobShares[0].NamespaceID(), Shares: blobShares}.RawData()
+}
+
+// partialExtendedRow returns the known shares of row in the top half of an
+// extended data square with an original width of squareSize. Missing shares
+// are nil.
+func partialExtendedRow(available map[int]*Share, rowParity map[int][]*Share, row, squareSize int) ([]*Share, error) {
+	extendedRow := make([]*Share, 2*squareSize)
+	for col := 0; col < squareSize; col++ {
+		extendedRow[col] = available[row*squareSize+col]
+	}
+	parity, ok := rowParity[row]
+	if !ok {
+		return extendedRow, nil
+	}
+	if len(parity) != squareSize {
+		return nil, fmt.Errorf("row %d has %d parity shares but must have %d", row, len(parity), squareSize)
+	}
+	copy(extendedRow[squareSize:], parity)
+	return extendedRow, nil
+}
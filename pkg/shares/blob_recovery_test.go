@@ -0,0 +1,88 @@
+package shares
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coretypes "github.com/tendermint/tendermint/types"
+)
+
+func TestReconstructBlobFromPartial(t *testing.T) {
+	squareSize := 4
+	txs := TxsToBytes(generateRandomTxs(2, 100))
+	blob := generateRandomBlobWithNamespace(nsOne, 2000)
+	original, err := BuildSquare(txs, []coretypes.Blob{blob}, squareSize)
+	require.NoError(t, err)
+	extended, err := ExtendSquare(original, squareSize)
+	require.NoError(t, err)
+
+	// the tx share occupies index 0, reserved padding occupies index 1, and
+	// the blob occupies 4 shares starting at the next multiple of its min
+	// square size (2) so it spans rows 0 and 1
+	blobStartIndex, totalShares := 2, 4
+	require.Equal(t, totalShares, SparseSharesNeeded(uint32(len(blob.Data))))
+
+	// partial returns the shares of the original data square and the parity
+	// shares of the top half of the extended data square with the shares at
+	// missing removed
+	partial := func(missing ...[2]int) (map[int]*Share, map[int][]*Share) {
+		isMissing := func(row, col int) bool {
+			for _, m := range missing {
+				if m == [2]int{row, col} {
+					return true
+				}
+			}
+			return false
+		}
+		available := make(map[int]*Share)
+		rowParity := make(map[int][]*Share)
+		for row := 0; row < squareSize; row++ {
+			rowParity[row] = make([]*Share, squareSize)
+			for col := 0; col < 2*squareSize; col++ {
+				if isMissing(row, col) {
+					continue
+				}
+				if col < squareSize {
+					available[row*squareSize+col] = &extended[row][col]
+				} else {
+					rowParity[row][col-squareSize] = &extended[row][col]
+				}
+			}
+		}
+		return available, rowParity
+	}
+
+	t.Run("all shares available", func(t *testing.T) {
+		available, rowParity := partial()
+		got, err := ReconstructBlobFromPartial(available, blobStartIndex, totalShares, rowParity, squareSize)
+		require.NoError(t, err)
+		assert.Equal(t, blob.Data, got)
+	})
+	t.Run("missing shares in each row", func(t *testing.T) {
+		available, rowParity := partial([2]int{0, 2}, [2]int{0, 3}, [2]int{0, 5}, [2]int{1, 0}, [2]int{1, 1})
+		got, err := ReconstructBlobFromPartial(available, blobStartIndex, totalShares, rowParity, squareSize)
+		require.NoError(t, err)
+		assert.Equal(t, blob.Data, got)
+	})
+	t.Run("without row parity", func(t *testing.T) {
+		available, _ := partial([2]int{0, 2})
+		_, err := ReconstructBlobFromPartial(available, blobStartIndex, totalShares, nil, squareSize)
+		assert.Error(t, err)
+	})
+	t.Run("too many missing shares in a row", func(t *testing.T) {
+		available, rowParity := partial([2]int{1, 0}, [2]int{1, 1}, [2]int{1, 4}, [2]int{1, 5}, [2]int{1, 6})
+		_, err := ReconstructBlobFromPartial(available, blobStartIndex, totalShares, rowParity, squareSize)
+		assert.Error(t, err)
+	})
+	t.Run("wrong total shares", func(t *testing.T) {
+		available, rowParity := partial()
+		_, err := ReconstructBlobFromPartial(available, blobStartIndex, totalShares-1, rowParity, squareSize)
+		assert.Error(t, err)
+	})
+	t.Run("blob does not fit in the square", func(t *testing.T) {
+		available, rowParity := partial()
+		_, err := ReconstructBlobFromPartial(available, 14, totalShares, rowParity, squareSize)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,103 @@
+package shares
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+
+	"github.com/celestiaorg/nmt/namespace"
+)
+
+const (
+	// bloomFilterBitsPerNamespace is the number of bits of a BloomFilter per
+	// namespace added to it. Along with bloomFilterHashCount, this results in
+	// a false positive rate of roughly 1%.
+	bloomFilterBitsPerNamespace = 10
+	// bloomFilterHashCount is the number of bits set per namespace added to a
+	// BloomFilter.
+	bloomFilterHashCount = 7
+	// bloomFilterMinBits is the minimum number of bits in a BloomFilter.
+	bloomFilterMinBits = 64
+)
+
+// BloomFilter is a probabilistic set of namespace IDs. Contains never returns
+// false for a namespace that was added to the filter but may return true for a
+// namespace that wasn't. A BloomFilter can be serialized with MarshalBinary so
+// that it can be cached alongside block metadata.
+type BloomFilter struct {
+	bits      []byte
+	hashCount uint8
+}
+
+// NamespaceBloomFilter returns a BloomFilter that contains the namespace ID of
+// every share in shares.
+func NamespaceBloomFilter(shares []Share) (*BloomFilter, error) {
+	namespaces := make(map[string]struct{})
+	for i := range shares {
+		ns, err := shares[i].namespaceIDOrErr()
+		if err != nil {
+			return nil, err
+		}
+		namespaces[string(ns)] = struct{}{}
+	}
+
+	bitCount := len(namespaces) * bloomFilterBitsPerNamespace
+	if bitCount < bloomFilterMinBits {
+		bitCount = bloomFilterMinBits
+	}
+	filter := &BloomFilter{
+		bits:      make([]byte, (bitCount+7)/8),
+		hashCount: bloomFilterHashCount,
+	}
+	for ns := range namespaces {
+		for _, bit := range filter.bitIndexes(namespace.ID(ns)) {
+			filter.bits[bit/8] |= 1 << (bit % 8)
+		}
+	}
+	return filter, nil
+}
+
+// Contains returns true if ns may have been added to the filter and false if
+// it definitely wasn't.
+func (f *BloomFilter) Contains(ns namespace.ID) bool {
+	for _, bit := range f.bitIndexes(ns) {
+		if f.bits[bit/8]&(1<<(bit%8)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// MarshalBinary encodes the filter as its hash count followed by its bits.
+func (f *BloomFilter) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, 1+len(f.bits))
+	data = append(data, f.hashCount)
+	return append(data, f.bits...), nil
+}
+
+// UnmarshalBinary decodes a filter encoded by MarshalBinary.
+func (f *BloomFilter) UnmarshalBinary(data []byte) error {
+	if len(data) < 2 {
+		return errors.New("bloom filter data must contain a hash count and at least one byte of bits")
+	}
+	if data[0] == 0 {
+		return errors.New("bloom filter hash count must be positive")
+	}
+	f.hashCount = data[0]
+	f.bits = append([]byte{}, data[1:]...)
+	return nil
+}
+
+// bitIndexes returns the indexes of the bits of the filter that are set for
+// ns. The indexes are derived from a SHA-256 hash of ns using double hashing.
+func (f *BloomFilter) bitIndexes(ns namespace.ID) []uint64 {
+	sum := sha256.Sum256(ns)
+	h1 := binary.BigEndian.Uint64(sum[0:8])
+	h2 := binary.BigEndian.Uint64(sum[8:16])
+	bitCount := uint64(len(f.bits)) * 8
+	indexes := make([]uint64, f.hashCount)
+	for i := range indexes {
+		indexes[i] = (h1 + uint64(i)*h2) % bitCount
+	}
+	return indexes
+}
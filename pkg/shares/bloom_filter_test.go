@@ -0,0 +1,42 @@
+package shares
+
+import (
+	"testing"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/nmt/namespace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coretypes "github.com/tendermint/tendermint/types"
+)
+
+func TestNamespaceBloomFilter(t *testing.T) {
+	nsTwo := namespace.ID{2, 2, 2, 2, 2, 2, 2, 2}
+	txs := TxsToBytes(generateRandomTxs(2, 100))
+	blobs := []coretypes.Blob{
+		generateRandomBlobWithNamespace(nsOne, 100),
+		generateRandomBlobWithNamespace(nsTwo, 100),
+	}
+	shares, err := BuildSquare(txs, blobs, 4)
+	require.NoError(t, err)
+
+	filter, err := NamespaceBloomFilter(shares)
+	require.NoError(t, err)
+	for _, ns := range []namespace.ID{appconsts.TxNamespaceID, nsOne, nsTwo, appconsts.TailPaddingNamespaceID} {
+		assert.True(t, filter.Contains(ns), "namespace %x", ns)
+	}
+	assert.False(t, filter.Contains(namespace.ID{3, 3, 3, 3, 3, 3, 3, 3}))
+
+	data, err := filter.MarshalBinary()
+	require.NoError(t, err)
+	decoded := &BloomFilter{}
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	assert.Equal(t, filter, decoded)
+	assert.True(t, decoded.Contains(nsOne))
+
+	assert.Error(t, decoded.UnmarshalBinary([]byte{bloomFilterHashCount}))
+	assert.Error(t, decoded.UnmarshalBinary([]byte{0, 0xFF}))
+
+	_, err = NamespaceBloomFilter([]Share{{data: []byte{1}}})
+	assert.Error(t, err)
+}
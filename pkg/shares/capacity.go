@@ -0,0 +1,174 @@
+package shares
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/nmt/namespace"
+	"golang.org/x/exp/slices"
+)
+
+// PayloadCapacity returns the number of bytes available for data in a share
+// with the provided share version, sequence start indicator, and type
+// (compact or sparse). The start share of a sequence has less capacity than a
+// continuation share because it contains the sequence length and compact
+// shares have less capacity than sparse shares because they contain reserved
+// bytes. It returns an error if version is not a supported share version.
+func PayloadCapacity(version uint8, isSequenceStart, isCompact bool) (int, error) {
+	if !slices.Contains(appconsts.SupportedShareVersions, version) {
+		return 0, fmt.Errorf("unsupported share version %d is not present in the list of supported share versions %v", version, appconsts.SupportedShareVersions)
+	}
+	switch {
+	case isSequenceStart && isCompact:
+		return appconsts.FirstCompactShareContentSize, nil
+	case isSequenceStart && !isCompact:
+		return appconsts.FirstSparseShareContentSize, nil
+	case !isSequenceStart && isCompact:
+		return appconsts.ContinuationCompactShareContentSize, nil
+	default:
+		return appconsts.ContinuationSparseShareContentSize, nil
+	}
+}
+
+// StartShareCapacity returns the number of bytes available for data in the
+// first share of a sequence with the provided share version and type (compact
+// or sparse). It returns 0 if version is not a supported share version.
+func StartShareCapacity(version uint8, isCompact bool) int {
+	capacity, err := PayloadCapacity(version, true, isCompact)
+	if err != nil {
+		return 0
+	}
+	return capacity
+}
+
+// ContinuationShareCapacity returns the number of bytes available for data in
+// a continuation share of a sequence with the provided share version and type
+// (compact or sparse). It returns 0 if version is not a supported share
+// version.
+func ContinuationShareCapacity(version uint8, isCompact bool) int {
+	capacity, err := PayloadCapacity(version, false, isCompact)
+	if err != nil {
+		return 0
+	}
+	return capacity
+}
+
+// PayloadCapacity returns the number of bytes available for data in this
+// share. See PayloadCapacity for details.
+func (s *Share) PayloadCapacity() (int, error) {
+	if s.isParity {
+		return 0, errors.New("parity shares do not contain a payload")
+	}
+	if _, err := s.namespaceIDOrErr(); err != nil {
+		return 0, err
+	}
+	infoByte, err := s.InfoByte()
+	if err != nil {
+		return 0, err
+	}
+	return PayloadCapacity(infoByte.Version(), infoByte.IsSequenceStart(), s.IsCompactShare())
+}
+
+// ChunkBlobForSquare splits data into chunks that each fit in at most
+// maxSharesPerSquare sparse shares of the provided share version so that each
+// chunk can be submitted as its own blob. Every chunk except the last one is
+// as large as possible. The chunks returned alias data.
+func ChunkBlobForSquare(data []byte, version uint8, maxSharesPerSquare int) ([][]byte, error) {
+	if len(data) == 0 {
+		return nil, errors.New("cannot chunk a blob with no data")
+	}
+	if maxSharesPerSquare <= 0 {
+		return nil, fmt.Errorf("max shares per square %d must be positive", maxSharesPerSquare)
+	}
+	firstCapacity, err := PayloadCapacity(version, true, false)
+	if err != nil {
+		return nil, err
+	}
+	continuationCapacity, err := PayloadCapacity(version, false, false)
+	if err != nil {
+		return nil, err
+	}
+	chunkSize := firstCapacity + (maxSharesPerSquare-1)*continuationCapacity
+
+	chunks := make([][]byte, 0, (len(data)+chunkSize-1)/chunkSize)
+	for len(data) > chunkSize {
+		chunks = append(chunks, data[:chunkSize:chunkSize])
+		data = data[chunkSize:]
+	}
+	return append(chunks, data), nil
+}
+
+// MergeCrossBlockBlob returns the payload that was split into chunks by
+// ChunkBlobForSquare and submitted in multiple blocks. chunks contains the
+// shares of each chunk's blob in the order the chunks were split. It returns an
+// error if the shares of a chunk are not exactly one blob or if the blobs of
+// the chunks don't have the same namespace and share version.
+func MergeCrossBlockBlob(chunks [][]Share) ([]byte, error) {
+	if len(chunks) == 0 {
+		return nil, errors.New("no chunks provided")
+	}
+	var (
+		ns      namespace.ID
+		version uint8
+		merged  []byte
+	)
+	for i, chunk := range chunks {
+		if err := validateSingleSequence(chunk); err != nil {
+			return nil, fmt.Errorf("chunk %d is not a complete blob: %w", i, err)
+		}
+		if chunk[0].IsCompactShare() {
+			return nil, fmt.Errorf("chunk %d contains compact shares instead of a blob", i)
+		}
+		chunkVersion, err := chunk[0].Version()
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			ns, version = chunk[0].NamespaceID(), chunkVersion
+		}
+		if !chunk[0].NamespaceID().Equal(ns) {
+			return nil, fmt.Errorf("chunk %d has namespace %x but the first chunk has namespace %x", i, chunk[0].NamespaceID(), ns)
+		}
+		if chunkVersion != version {
+			return nil, fmt.Errorf("chunk %d has share version %d but the first chunk has share version %d", i, chunkVersion, version)
+		}
+		data, err := ShareSequence{NamespaceID: ns, Shares: chunk}.RawData()
+		if err != nil {
+			return nil, err
+		}
+		merged = append(merged, data...)
+	}
+	return merged, nil
+}
+
+// PredictRemainingShares returns the total number of shares occupied by the
+// sequence that starts with startShare, including startShare itself, so that a
+// client streaming the shares of a sequence can report progress before the
+// remaining shares arrive. It returns an error if startShare is not the start
+// of a sequence, if its share version is not version, or if version is not a
+// supported share version.
+func PredictRemainingShares(startShare *Share, version uint8) (int, error) {
+	if err := startShare.Validate(); err != nil {
+		return 0, err
+	}
+	infoByte, err := startShare.InfoByte()
+	if err != nil {
+		return 0, err
+	}
+	if !infoByte.IsSequenceStart() {
+		return 0, errors.New("share is not the start of a sequence")
+	}
+	if infoByte.Version() != version {
+		return 0, fmt.Errorf("share has share version %d but expected share version %d", infoByte.Version(), version)
+	}
+	if StartShareCapacity(version, startShare.IsCompactShare()) == 0 {
+		return 0, fmt.Errorf("unsupported share version %d is not present in the list of supported share versions %v", version, appconsts.SupportedShareVersions)
+	}
+
+	sequenceLen, err := startShare.SequenceLen()
+	if err != nil {
+		return 0, err
+	}
+	return sharesOccupied(sequenceLen, startShare.IsCompactShare()), nil
+}
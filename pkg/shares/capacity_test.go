@@ -0,0 +1,224 @@
+package shares
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/nmt/namespace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coretypes "github.com/tendermint/tendermint/types"
+)
+
+func TestPayloadCapacity(t *testing.T) {
+	type testCase struct {
+		name            string
+		version         uint8
+		isSequenceStart bool
+		isCompact       bool
+		want            int
+		wantErr         bool
+	}
+	testCases := []testCase{
+		{name: "first compact share", version: appconsts.ShareVersionZero, isSequenceStart: true, isCompact: true, want: 495},
+		{name: "first sparse share", version: appconsts.ShareVersionZero, isSequenceStart: true, isCompact: false, want: 499},
+		{name: "continuation compact share", version: appconsts.ShareVersionZero, isSequenceStart: false, isCompact: true, want: 499},
+		{name: "continuation sparse share", version: appconsts.ShareVersionZero, isSequenceStart: false, isCompact: false, want: 503},
+		{name: "unsupported version first compact share", version: 1, isSequenceStart: true, isCompact: true, wantErr: true},
+		{name: "unsupported version first sparse share", version: 1, isSequenceStart: true, isCompact: false, wantErr: true},
+		{name: "unsupported version continuation compact share", version: 1, isSequenceStart: false, isCompact: true, wantErr: true},
+		{name: "unsupported version continuation sparse share", version: 1, isSequenceStart: false, isCompact: false, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := PayloadCapacity(tc.version, tc.isSequenceStart, tc.isCompact)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestSharePayloadCapacity(t *testing.T) {
+	type testCase struct {
+		name    string
+		share   Share
+		want    int
+		wantErr bool
+	}
+	testCases := []testCase{
+		{name: "first compact share", share: shareWithData(appconsts.TxNamespaceID, true, 1, []byte{1}), want: appconsts.FirstCompactShareContentSize},
+		{name: "first sparse share", share: shareWithData(nsOne, true, 1, []byte{1}), want: appconsts.FirstSparseShareContentSize},
+		{name: "continuation compact share", share: shareWithData(appconsts.PayForBlobNamespaceID, false, 0, []byte{1}), want: appconsts.ContinuationCompactShareContentSize},
+		{name: "continuation sparse share", share: shareWithData(nsOne, false, 0, []byte{1}), want: appconsts.ContinuationSparseShareContentSize},
+		{name: "no info byte", share: Share{data: []byte{1, 1, 1, 1, 1, 1, 1, 1}}, wantErr: true},
+		{name: "no namespace", share: Share{data: []byte{1}}, wantErr: true},
+		{name: "parity share", share: Share{data: make([]byte, appconsts.ShareSize), isParity: true}, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.share.PayloadCapacity()
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestChunkBlobForSquare(t *testing.T) {
+	type testCase struct {
+		name               string
+		dataLen            int
+		maxSharesPerSquare int
+		wantChunkLens      []int
+	}
+	testCases := []testCase{
+		{"fits in one share", 100, 1, []int{100}},
+		{"exactly one share", appconsts.FirstSparseShareContentSize, 1, []int{appconsts.FirstSparseShareContentSize}},
+		{"one byte more than one share", appconsts.FirstSparseShareContentSize + 1, 1, []int{appconsts.FirstSparseShareContentSize, 1}},
+		{
+			"two shares per square",
+			3 * appconsts.ShareSize,
+			2,
+			[]int{
+				appconsts.FirstSparseShareContentSize + appconsts.ContinuationSparseShareContentSize,
+				3*appconsts.ShareSize - appconsts.FirstSparseShareContentSize - appconsts.ContinuationSparseShareContentSize,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data := bytes.Repeat([]byte{1}, tc.dataLen)
+			chunks, err := ChunkBlobForSquare(data, appconsts.ShareVersionZero, tc.maxSharesPerSquare)
+			require.NoError(t, err)
+
+			lens := make([]int, len(chunks))
+			for i, chunk := range chunks {
+				lens[i] = len(chunk)
+				assert.LessOrEqual(t, SparseSharesNeeded(uint32(len(chunk))), tc.maxSharesPerSquare)
+			}
+			assert.Equal(t, tc.wantChunkLens, lens)
+			assert.Equal(t, data, bytes.Join(chunks, nil))
+		})
+	}
+}
+
+func TestChunkBlobForSquareErrors(t *testing.T) {
+	_, err := ChunkBlobForSquare(nil, appconsts.ShareVersionZero, 1)
+	assert.Error(t, err)
+	_, err = ChunkBlobForSquare([]byte{1}, appconsts.ShareVersionZero, 0)
+	assert.Error(t, err)
+	_, err = ChunkBlobForSquare([]byte{1}, 1, 1)
+	assert.Error(t, err)
+}
+
+func TestStartAndContinuationShareCapacity(t *testing.T) {
+	assert.Equal(t, appconsts.FirstSparseShareContentSize, StartShareCapacity(appconsts.ShareVersionZero, false))
+	assert.Equal(t, appconsts.FirstCompactShareContentSize, StartShareCapacity(appconsts.ShareVersionZero, true))
+	assert.Equal(t, appconsts.ContinuationSparseShareContentSize, ContinuationShareCapacity(appconsts.ShareVersionZero, false))
+	assert.Equal(t, appconsts.ContinuationCompactShareContentSize, ContinuationShareCapacity(appconsts.ShareVersionZero, true))
+
+	// the start share has less capacity because it contains the sequence length
+	for _, isCompact := range []bool{true, false} {
+		assert.Equal(t, appconsts.SequenceLenBytes, ContinuationShareCapacity(appconsts.ShareVersionZero, isCompact)-StartShareCapacity(appconsts.ShareVersionZero, isCompact))
+	}
+
+	assert.Zero(t, StartShareCapacity(1, false))
+	assert.Zero(t, ContinuationShareCapacity(1, false))
+}
+
+func TestPredictRemainingShares(t *testing.T) {
+	// unsupportedVersion is the start of a sequence with the max share version
+	unsupportedVersion := shareWithData(nsOne, true, 1, []byte{1})
+	unsupportedVersion.data[appconsts.NamespaceSize] = 0xFF
+
+	type testCase struct {
+		name    string
+		share   Share
+		version uint8
+		want    int
+		wantErr bool
+	}
+	testCases := []testCase{
+		{name: "empty sparse sequence", share: shareWithData(nsOne, true, 0, nil), want: 1},
+		{name: "sparse sequence that fills the start share", share: shareWithData(nsOne, true, appconsts.FirstSparseShareContentSize, nil), want: 1},
+		{name: "sparse sequence with one continuation share", share: shareWithData(nsOne, true, appconsts.FirstSparseShareContentSize+1, nil), want: 2},
+		{name: "sparse sequence that fills two continuation shares", share: shareWithData(nsOne, true, appconsts.FirstSparseShareContentSize+2*appconsts.ContinuationSparseShareContentSize, nil), want: 3},
+		{name: "compact sequence that fills the start share", share: shareWithData(appconsts.TxNamespaceID, true, appconsts.FirstCompactShareContentSize, nil), want: 1},
+		{name: "compact sequence with one continuation share", share: shareWithData(appconsts.TxNamespaceID, true, appconsts.FirstCompactShareContentSize+1, nil), want: 2},
+		{name: "continuation share", share: shareWithData(nsOne, false, 0, nil), wantErr: true},
+		{name: "mismatched share version", share: shareWithData(nsOne, true, 1, nil), version: 1, wantErr: true},
+		{name: "unsupported share version", share: unsupportedVersion, version: appconsts.MaxShareVersion, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := PredictRemainingShares(&tc.share, tc.version)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	// the prediction matches the number of shares a blob is split into
+	for _, size := range []int{1, 499, 500, 1002, 1003, 5000} {
+		shares, err := SplitBlobs(0, nil, []coretypes.Blob{generateRandomBlobWithNamespace(nsOne, size)}, false)
+		require.NoError(t, err)
+		got, err := PredictRemainingShares(&shares[0], appconsts.ShareVersionZero)
+		require.NoError(t, err)
+		assert.Equal(t, len(shares), got, "blob of size %d", size)
+	}
+}
+
+func TestMergeCrossBlockBlob(t *testing.T) {
+	data := bytes.Repeat([]byte{1, 2, 3}, 1000)
+	chunks, err := ChunkBlobForSquare(data, appconsts.ShareVersionZero, 2)
+	require.NoError(t, err)
+	require.Greater(t, len(chunks), 1)
+
+	// splitChunk returns the shares of a blob in ns that contains chunk
+	splitChunk := func(ns namespace.ID, chunk []byte) []Share {
+		blob := coretypes.Blob{NamespaceID: ns, Data: chunk, ShareVersion: appconsts.ShareVersionZero}
+		shares, err := SplitBlobs(0, nil, []coretypes.Blob{blob}, false)
+		require.NoError(t, err)
+		return shares
+	}
+	chunkShares := make([][]Share, len(chunks))
+	for i, chunk := range chunks {
+		chunkShares[i] = splitChunk(nsOne, chunk)
+	}
+
+	got, err := MergeCrossBlockBlob(chunkShares)
+	require.NoError(t, err)
+	assert.Equal(t, data, got)
+
+	t.Run("different namespaces", func(t *testing.T) {
+		mixed := append([][]Share{}, chunkShares...)
+		mixed[1] = splitChunk(namespace.ID{2, 2, 2, 2, 2, 2, 2, 2}, chunks[1])
+		_, err := MergeCrossBlockBlob(mixed)
+		assert.Error(t, err)
+	})
+	t.Run("incomplete chunk", func(t *testing.T) {
+		incomplete := append([][]Share{}, chunkShares...)
+		incomplete[0] = incomplete[0][:1]
+		_, err := MergeCrossBlockBlob(incomplete)
+		assert.Error(t, err)
+	})
+	t.Run("no chunks", func(t *testing.T) {
+		_, err := MergeCrossBlockBlob(nil)
+		assert.Error(t, err)
+	})
+}
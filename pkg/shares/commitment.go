@@ -0,0 +1,193 @@
+package shares
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"hash"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/nmt"
+	"github.com/celestiaorg/nmt/namespace"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	coretypes "github.com/tendermint/tendermint/types"
+)
+
+// SubtreeRoots returns the namespaced Merkle roots of the subtrees that a
+// blob's shares are split into for its share commitment. The shares are split
+// into a merkle mountain range where every tree has at most subtreeWidth
+// leaves. hasher is the base hash function used to construct each namespaced
+// Merkle tree (e.g. appconsts.NewBaseHashFunc()). See
+// https://github.com/celestiaorg/celestia-app/blob/fbfbf111bcaa056e53b0bc54d327587dee11a945/docs/architecture/adr-008-blocksize-independent-commitment.md
+func SubtreeRoots(shares []Share, subtreeWidth int, hasher hash.Hash) ([][]byte, error) {
+	if len(shares) == 0 {
+		return nil, errors.New("cannot compute subtree roots of zero shares")
+	}
+	if !IsPowerOfTwo(subtreeWidth) {
+		return nil, fmt.Errorf("subtree width %d must be a power of two", subtreeWidth)
+	}
+	treeSizes, err := merkleMountainRangeSizes(uint64(len(shares)), uint64(subtreeWidth))
+	if err != nil {
+		return nil, err
+	}
+
+	subtreeRoots := make([][]byte, len(treeSizes))
+	cursor := 0
+	for i, treeSize := range treeSizes {
+		tree := nmt.New(hasher, nmt.NamespaceIDSize(appconsts.NamespaceSize))
+		for _, share := range shares[cursor : cursor+int(treeSize)] {
+			if err := share.Validate(); err != nil {
+				return nil, err
+			}
+			// the namespace is prepended to each share to match the leaves
+			// of the row roots. See wrapper.ErasuredNamespacedMerkleTree.
+			if err := tree.Push(namespacedLeaf(share)); err != nil {
+				return nil, err
+			}
+		}
+		subtreeRoots[i] = tree.Root()
+		cursor += int(treeSize)
+	}
+	return subtreeRoots, nil
+}
+
+// VerifyBlobCommitment returns an error if the share commitment computed over
+// the blob's shares doesn't match commitment. subtreeWidth is the maximum
+// number of leaves in each subtree of the commitment which is the minimum
+// square size the blob can be included in for commitments created by
+// PayForBlobs transactions.
+func VerifyBlobCommitment(shares []Share, commitment []byte, subtreeWidth int, hasher hash.Hash) error {
+	got, err := computeCommitment(shares, subtreeWidth, hasher)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(got, commitment) {
+		return fmt.Errorf("blob commitment %x does not match expected commitment %x", got, commitment)
+	}
+	return nil
+}
+
+// AllNamespaceCommitments returns a map from the hex encoded namespace ID of
+// each blob namespace in shares to the share commitments of the blobs in that
+// namespace, in the order the blobs appear in shares. Each commitment is
+// computed over the shares of one blob with subtrees of at most subtreeWidth
+// leaves so it matches the commitment of the PayForBlobs transaction that paid
+// for the blob. Compact shares and padding shares are skipped. It returns an
+// error if the shares of a namespace are not contiguous.
+func AllNamespaceCommitments(shares []Share, subtreeWidth int, hasher hash.Hash) (map[string][][]byte, error) {
+	ranges, err := BlobNamespaceRanges(shares)
+	if err != nil {
+		return nil, err
+	}
+	commitments := make(map[string][][]byte, len(ranges))
+	for key, r := range ranges {
+		blobs, err := splitBlobShares(shares[r[0]:r[1]])
+		if err != nil {
+			return nil, err
+		}
+		for _, blobShares := range blobs {
+			commitment, err := computeCommitment(blobShares, subtreeWidth, hasher)
+			if err != nil {
+				return nil, err
+			}
+			commitments[key] = append(commitments[key], commitment)
+		}
+	}
+	return commitments, nil
+}
+
+// splitBlobShares splits the sparse shares of consecutive blobs into the shares
+// of each blob. Namespace padding between the blobs is skipped.
+func splitBlobShares(shares []Share) ([][]Share, error) {
+	blobs := [][]Share{}
+	for _, share := range shares {
+		isPadding, err := share.IsPadding()
+		if err != nil {
+			return nil, err
+		}
+		if isPadding {
+			continue
+		}
+		isStart, err := share.IsSequenceStart()
+		if err != nil {
+			return nil, err
+		}
+		if isStart {
+			blobs = append(blobs, []Share{})
+		}
+		if len(blobs) == 0 {
+			return nil, errors.New("blob shares don't begin with the start of a sequence")
+		}
+		blobs[len(blobs)-1] = append(blobs[len(blobs)-1], share)
+	}
+	return blobs, nil
+}
+
+// computeCommitment returns the share commitment over shares: the Merkle root
+// of the subtree roots of shares.
+func computeCommitment(shares []Share, subtreeWidth int, hasher hash.Hash) ([]byte, error) {
+	subtreeRoots, err := SubtreeRoots(shares, subtreeWidth, hasher)
+	if err != nil {
+		return nil, err
+	}
+	return merkle.HashFromByteSlices(subtreeRoots), nil
+}
+
+// SplitBlobAligned splits a blob into shares and appends namespace padding
+// shares so that the number of shares returned is a multiple of subtreeWidth.
+// Every subtree of a commitment computed over the returned shares is therefore
+// a full subtree of subtreeWidth leaves. Note that PayForBlobs commitments are
+// computed over the blob's shares without padding so the commitment over the
+// returned shares only matches the PayForBlobs commitment if no padding was
+// needed.
+func SplitBlobAligned(ns namespace.ID, shareVersion uint8, data []byte, subtreeWidth int) ([]Share, error) {
+	if !IsPowerOfTwo(subtreeWidth) {
+		return nil, fmt.Errorf("subtree width %d must be a power of two", subtreeWidth)
+	}
+	writer := NewSparseShareSplitter()
+	blob := coretypes.Blob{NamespaceID: ns, Data: data, ShareVersion: shareVersion}
+	if err := writer.Write(blob); err != nil {
+		return nil, err
+	}
+	padding := paddingToSubtreeBoundary(writer.Count(), subtreeWidth)
+	if err := writer.WriteNamespacedPaddedShares(padding); err != nil {
+		return nil, err
+	}
+	return writer.Export(), nil
+}
+
+// paddingToSubtreeBoundary returns the number of padding shares needed to
+// round shareCount up to the next multiple of subtreeWidth.
+func paddingToSubtreeBoundary(shareCount, subtreeWidth int) int {
+	if remainder := shareCount % subtreeWidth; remainder != 0 {
+		return subtreeWidth - remainder
+	}
+	return 0
+}
+
+// merkleMountainRangeSizes returns the sizes (number of leaf nodes) of the
+// trees in a merkle mountain range constructed for a given totalSize and
+// maxTreeSize.
+//
+// https://docs.grin.mw/wiki/chain-state/merkle-mountain-range/
+// https://github.com/opentimestamps/opentimestamps-server/blob/master/doc/merkle-mountain-range.md
+func merkleMountainRangeSizes(totalSize, maxTreeSize uint64) ([]uint64, error) {
+	var treeSizes []uint64
+
+	for totalSize != 0 {
+		switch {
+		case totalSize >= maxTreeSize:
+			treeSizes = append(treeSizes, maxTreeSize)
+			totalSize = totalSize - maxTreeSize
+		case totalSize < maxTreeSize:
+			treeSize, err := RoundDownPowerOfTwo(totalSize)
+			if err != nil {
+				return treeSizes, err
+			}
+			treeSizes = append(treeSizes, treeSize)
+			totalSize = totalSize - treeSize
+		}
+	}
+
+	return treeSizes, nil
+}
@@ -0,0 +1,226 @@
+package shares
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/nmt/namespace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coretypes "github.com/tendermint/tendermint/types"
+)
+
+func TestVerifyBlobCommitment(t *testing.T) {
+	type testCase struct {
+		name       string
+		blob       coretypes.Blob
+		commitment []byte
+	}
+	// these commitments were created by MsgPayForBlobs CreateCommitment
+	testCases := []testCase{
+		{
+			name: "blob of 11 shares",
+			blob: coretypes.Blob{
+				NamespaceID:  bytes.Repeat([]byte{0xFF}, 8),
+				Data:         bytes.Repeat([]byte{0xFF}, 11*appconsts.ShareSize),
+				ShareVersion: appconsts.ShareVersionZero,
+			},
+			commitment: []byte{0x9f, 0x44, 0xd5, 0x12, 0xe9, 0x6b, 0xea, 0xb3, 0xf2, 0xfe, 0x7b, 0x46, 0xc6, 0x4c, 0xee, 0x70, 0xb0, 0x86, 0xca, 0x94, 0x7e, 0x1b, 0x95, 0xd2, 0x0, 0x78, 0x32, 0xb5, 0x94, 0x68, 0x67, 0xf0},
+		},
+		{
+			name: "blob of 12 shares",
+			blob: coretypes.Blob{
+				NamespaceID:  bytes.Repeat([]byte{0xFF}, 8),
+				Data:         bytes.Repeat([]byte{0xFF}, 12*appconsts.ShareSize),
+				ShareVersion: appconsts.ShareVersionZero,
+			},
+			commitment: []byte{0xc0, 0x1a, 0xd7, 0xef, 0x37, 0x37, 0x9f, 0x62, 0x9c, 0x3a, 0x9, 0x9a, 0x5a, 0x1b, 0xff, 0xb7, 0x7a, 0xfa, 0xf6, 0x61, 0x19, 0x5b, 0x1a, 0xdb, 0x21, 0x84, 0x4, 0xac, 0x42, 0x7f, 0xec, 0xdf},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			shares, err := SplitBlobs(0, nil, []coretypes.Blob{tc.blob}, false)
+			require.NoError(t, err)
+			subtreeWidth := MinSquareSize(len(shares))
+
+			err = VerifyBlobCommitment(shares, tc.commitment, subtreeWidth, appconsts.NewBaseHashFunc())
+			assert.NoError(t, err)
+
+			err = VerifyBlobCommitment(shares[1:], tc.commitment, subtreeWidth, appconsts.NewBaseHashFunc())
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_merkleMountainRangeHeights(t *testing.T) {
+	type test struct {
+		totalSize  uint64
+		squareSize uint64
+		expected   []uint64
+	}
+	tests := []test{
+		{
+			totalSize:  11,
+			squareSize: 4,
+			expected:   []uint64{4, 4, 2, 1},
+		},
+		{
+			totalSize:  2,
+			squareSize: 64,
+			expected:   []uint64{2},
+		},
+		{
+			totalSize:  64,
+			squareSize: 8,
+			expected:   []uint64{8, 8, 8, 8, 8, 8, 8, 8},
+		},
+		// Height
+		// 3              x                               x
+		//              /    \                         /    \
+		//             /      \                       /      \
+		//            /        \                     /        \
+		//           /          \                   /          \
+		// 2        x            x                 x            x
+		//        /   \        /   \             /   \        /   \
+		// 1     x     x      x     x           x     x      x     x         x
+		//      / \   / \    / \   / \         / \   / \    / \   / \      /   \
+		// 0   0   1 2   3  4   5 6   7       8   9 10  11 12 13 14  15   16   17    18
+		{
+			totalSize:  19,
+			squareSize: 8,
+			expected:   []uint64{8, 8, 2, 1},
+		},
+	}
+	for _, tt := range tests {
+		res, err := merkleMountainRangeSizes(tt.totalSize, tt.squareSize)
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, res)
+	}
+}
+
+func TestSubtreeRoots(t *testing.T) {
+	blob := generateRandomBlobWithNamespace(nsOne, 11*appconsts.ShareSize)
+	shares, err := SplitBlobs(0, nil, []coretypes.Blob{blob}, false)
+	require.NoError(t, err)
+
+	// 12 shares with a subtree width of 8 form trees of size 8 and 4
+	roots, err := SubtreeRoots(shares, 8, appconsts.NewBaseHashFunc())
+	require.NoError(t, err)
+	assert.Len(t, roots, 2)
+
+	_, err = SubtreeRoots(shares, 3, appconsts.NewBaseHashFunc())
+	assert.Error(t, err)
+
+	_, err = SubtreeRoots(nil, 4, appconsts.NewBaseHashFunc())
+	assert.Error(t, err)
+}
+
+func TestSplitBlobAligned(t *testing.T) {
+	type testCase struct {
+		name         string
+		dataLen      int
+		subtreeWidth int
+		wantShares   int
+		wantPadding  int
+	}
+	testCases := []testCase{
+		{"one share blob", 100, 1, 1, 0},
+		{"one share blob with subtree width four", 100, 4, 4, 3},
+		{"two share blob with subtree width two", 1000, 2, 2, 0},
+		{"twelve share blob with subtree width eight", 11 * appconsts.ShareSize, 8, 16, 4},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			blob := generateRandomBlobWithNamespace(nsOne, tc.dataLen)
+			got, err := SplitBlobAligned(blob.NamespaceID, blob.ShareVersion, blob.Data, tc.subtreeWidth)
+			require.NoError(t, err)
+			assert.Len(t, got, tc.wantShares)
+
+			unaligned, err := SplitBlobs(0, nil, []coretypes.Blob{blob}, false)
+			require.NoError(t, err)
+			assert.Equal(t, unaligned, got[:len(unaligned)])
+			for _, share := range got[len(unaligned):] {
+				assert.Equal(t, nsOne, share.NamespaceID())
+				isPadding, err := share.IsPadding()
+				require.NoError(t, err)
+				assert.True(t, isPadding)
+			}
+			assert.Equal(t, tc.wantPadding, len(got)-len(unaligned))
+
+			roots, err := SubtreeRoots(got, tc.subtreeWidth, appconsts.NewBaseHashFunc())
+			require.NoError(t, err)
+			assert.Len(t, roots, tc.wantShares/tc.subtreeWidth)
+		})
+	}
+
+	t.Run("verifies against a PayForBlobs commitment when no padding is needed", func(t *testing.T) {
+		// this commitment was created by MsgPayForBlobs CreateCommitment
+		commitment := []byte{0x9f, 0x44, 0xd5, 0x12, 0xe9, 0x6b, 0xea, 0xb3, 0xf2, 0xfe, 0x7b, 0x46, 0xc6, 0x4c, 0xee, 0x70, 0xb0, 0x86, 0xca, 0x94, 0x7e, 0x1b, 0x95, 0xd2, 0x0, 0x78, 0x32, 0xb5, 0x94, 0x68, 0x67, 0xf0}
+		got, err := SplitBlobAligned(bytes.Repeat([]byte{0xFF}, 8), appconsts.ShareVersionZero, bytes.Repeat([]byte{0xFF}, 11*appconsts.ShareSize), 4)
+		require.NoError(t, err)
+		assert.NoError(t, VerifyBlobCommitment(got, commitment, 4, appconsts.NewBaseHashFunc()))
+	})
+
+	t.Run("invalid subtree width", func(t *testing.T) {
+		_, err := SplitBlobAligned(nsOne, appconsts.ShareVersionZero, []byte{1}, 3)
+		assert.Error(t, err)
+	})
+
+	t.Run("unsupported share version", func(t *testing.T) {
+		_, err := SplitBlobAligned(nsOne, 1, []byte{1}, 1)
+		assert.Error(t, err)
+	})
+}
+
+func TestAllNamespaceCommitments(t *testing.T) {
+	nsTwo := namespace.ID{2, 2, 2, 2, 2, 2, 2, 2}
+	blobOne := generateRandomBlobWithNamespace(nsOne, 100)
+	blobTwo := generateRandomBlobWithNamespace(nsTwo, 2000)
+	square, err := BuildSquare(TxsToBytes(generateRandomTxs(2, 100)), []coretypes.Blob{blobOne, blobTwo}, 4)
+	require.NoError(t, err)
+
+	got, err := AllNamespaceCommitments(square, 2, appconsts.NewBaseHashFunc())
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+
+	for _, blob := range []coretypes.Blob{blobOne, blobTwo} {
+		blobShares, err := SplitBlobs(0, nil, []coretypes.Blob{blob}, false)
+		require.NoError(t, err)
+		commitments, ok := got[hex.EncodeToString(blob.NamespaceID)]
+		require.True(t, ok)
+		require.Len(t, commitments, 1)
+		assert.NoError(t, VerifyBlobCommitment(blobShares, commitments[0], 2, appconsts.NewBaseHashFunc()))
+	}
+
+	t.Run("blobs of the same namespace have their own commitments", func(t *testing.T) {
+		blobs := []coretypes.Blob{blobOne, generateRandomBlobWithNamespace(nsOne, 1000)}
+		withPadding, err := SplitBlobs(0, []uint32{0, 4}, blobs, true)
+		require.NoError(t, err)
+		require.Len(t, withPadding, 6)
+
+		got, err := AllNamespaceCommitments(withPadding, 2, appconsts.NewBaseHashFunc())
+		require.NoError(t, err)
+		commitments := got[hex.EncodeToString(nsOne)]
+		require.Len(t, commitments, len(blobs))
+		for i, blob := range blobs {
+			blobShares, err := SplitBlobs(0, nil, []coretypes.Blob{blob}, false)
+			require.NoError(t, err)
+			assert.NoError(t, VerifyBlobCommitment(blobShares, commitments[i], 2, appconsts.NewBaseHashFunc()), "blob %d", i)
+		}
+	})
+
+	t.Run("no blobs", func(t *testing.T) {
+		got, err := AllNamespaceCommitments(square[:1], 2, appconsts.NewBaseHashFunc())
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("invalid subtree width", func(t *testing.T) {
+		_, err := AllNamespaceCommitments(square, 3, appconsts.NewBaseHashFunc())
+		assert.Error(t, err)
+	})
+}
@@ -1,7 +1,9 @@
 package shares
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -194,3 +196,120 @@ func Test_parseCompactSharesErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestParseAndValidateTxs(t *testing.T) {
+	txs := testfactory.GenerateRandomTxs(5, 200)
+	txShares, _, _, err := SplitTxs(txs)
+	require.NoError(t, err)
+
+	t.Run("all txs decode", func(t *testing.T) {
+		var decoded int
+		got, err := ParseAndValidateTxs(txShares, func([]byte) error {
+			decoded++
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, TxsToBytes(txs), got)
+		assert.Equal(t, len(txs), decoded)
+	})
+
+	t.Run("error names the tx that failed to decode", func(t *testing.T) {
+		_, err := ParseAndValidateTxs(txShares, func(tx []byte) error {
+			if bytes.Equal(tx, txs[3]) {
+				return errors.New("invalid tx")
+			}
+			return nil
+		})
+		assert.ErrorContains(t, err, "tx 3")
+	})
+}
+
+func TestValidateCompactFraming(t *testing.T) {
+	for _, txSize := range []int{10, 200, appconsts.ContinuationCompactShareContentSize, 2000} {
+		txShares, _, _, err := SplitTxs(testfactory.GenerateRandomTxs(5, txSize))
+		require.NoError(t, err)
+		assert.NoError(t, ValidateCompactFraming(txShares), "tx size %d", txSize)
+	}
+	assert.NoError(t, ValidateCompactFraming(nil))
+}
+
+func TestValidateCompactFramingErrors(t *testing.T) {
+	// copyShares returns a deep copy of shares so that each test case can
+	// corrupt its own shares
+	copyShares := func(shares []Share) []Share {
+		copied := make([]Share, len(shares))
+		for i, share := range shares {
+			copied[i] = Share{data: append([]byte{}, share.data...)}
+		}
+		return copied
+	}
+	txShares, _, _, err := SplitTxs(testfactory.GenerateRandomTxs(5, 200))
+	require.NoError(t, err)
+	require.Greater(t, len(txShares), 1)
+	firstReservedByte := appconsts.NamespaceSize + appconsts.ShareInfoBytes + appconsts.SequenceLenBytes + appconsts.CompactShareReservedBytes - 1
+	continuationReservedByte := appconsts.NamespaceSize + appconsts.ShareInfoBytes + appconsts.CompactShareReservedBytes - 1
+	sequenceLenByte := appconsts.NamespaceSize + appconsts.ShareInfoBytes + appconsts.SequenceLenBytes - 1
+
+	type testCase struct {
+		name    string
+		corrupt func(shares []Share) []Share
+		wantErr string
+	}
+	testCases := []testCase{
+		{
+			name: "reserved bytes of the first share",
+			corrupt: func(shares []Share) []Share {
+				shares[0].data[firstReservedByte]++
+				return shares
+			},
+			wantErr: "share 0: reserved bytes",
+		},
+		{
+			name: "reserved bytes of a continuation share",
+			corrupt: func(shares []Share) []Share {
+				shares[1].data[continuationReservedByte]++
+				return shares
+			},
+			wantErr: "share 1: reserved bytes",
+		},
+		{
+			name: "sequence length that ends mid unit",
+			corrupt: func(shares []Share) []Share {
+				shares[0].data[sequenceLenByte]--
+				return shares
+			},
+			wantErr: "overruns the sequence length",
+		},
+		{
+			name: "unit length that overruns the sequence",
+			corrupt: func(shares []Share) []Share {
+				rawDataStart := firstReservedByte + 1
+				shares[0].data[rawDataStart] = 0xFF
+				shares[0].data[rawDataStart+1] = 0x0F
+				return shares
+			},
+			wantErr: "share 0: unit",
+		},
+		{
+			name: "starts with a continuation share",
+			corrupt: func(shares []Share) []Share {
+				return shares[1:]
+			},
+			wantErr: "only the first share",
+		},
+		{
+			name: "sparse share",
+			corrupt: func(shares []Share) []Share {
+				return append(shares, shareWithData(nsOne, false, 0, []byte{1}))
+			},
+			wantErr: "is not a compact share",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateCompactFraming(tc.corrupt(copyShares(txShares)))
+			assert.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package shares
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+)
+
+// NamespaceLess returns true if the namespace ID of share a sorts strictly
+// before the namespace ID of share b. It returns an error if either share is
+// too short to contain a namespace ID. NamespaceLess can be used with
+// sort.Search to find the index at which a share should be inserted into a
+// slice of shares sorted by namespace.
+func NamespaceLess(a, b *Share) (bool, error) {
+	aNamespace, err := a.namespaceIDOrErr()
+	if err != nil {
+		return false, err
+	}
+	bNamespace, err := b.namespaceIDOrErr()
+	if err != nil {
+		return false, err
+	}
+	return bytes.Compare(aNamespace, bNamespace) < 0, nil
+}
+
+// SameNamespaceAndVersion returns true if shares a and b have the same
+// namespace ID and the same share version. It returns an error if either share
+// is too short to contain a namespace ID and info byte.
+func SameNamespaceAndVersion(a, b *Share) (bool, error) {
+	aNamespace, err := a.namespaceIDOrErr()
+	if err != nil {
+		return false, err
+	}
+	bNamespace, err := b.namespaceIDOrErr()
+	if err != nil {
+		return false, err
+	}
+	aVersion, err := a.Version()
+	if err != nil {
+		return false, err
+	}
+	bVersion, err := b.Version()
+	if err != nil {
+		return false, err
+	}
+	return aNamespace.Equal(bNamespace) && aVersion == bVersion, nil
+}
+
+// StableSortShares sorts shares by namespace ID in place. Shares with the same
+// namespace ID keep their original relative order so the shares of a blob
+// remain contiguous and in sequence order. It returns an error and leaves
+// shares unmodified if any share is too short to contain a namespace ID.
+func StableSortShares(shares []Share) error {
+	for i := range shares {
+		if _, err := shares[i].namespaceIDOrErr(); err != nil {
+			return err
+		}
+	}
+	sort.SliceStable(shares, func(i, j int) bool {
+		return bytes.Compare(shares[i].NamespaceID(), shares[j].NamespaceID()) < 0
+	})
+	return nil
+}
+
+// ShareByteDistance returns the number of bytes that differ between the data of
+// a and b (the Hamming distance over bytes). If a and b have different lengths,
+// every byte past the end of the shorter share counts as a difference.
+func ShareByteDistance(a, b *Share) int {
+	short, long := a.data, b.data
+	if len(short) > len(long) {
+		short, long = long, short
+	}
+	distance := len(long) - len(short)
+	for i := range short {
+		if short[i] != long[i] {
+			distance++
+		}
+	}
+	return distance
+}
+
+// CompactShareEqualIgnoringReserved returns true if the compact shares a and b
+// are equal except for their reserved bytes. Two framings of the same units
+// can differ only in the reserved bytes so this distinguishes shares with the
+// same content from shares with different content. It returns an error if a
+// or b is not a valid compact share.
+func CompactShareEqualIgnoringReserved(a, b *Share) (bool, error) {
+	aReservedEnd, err := compactReservedBytesEnd(a)
+	if err != nil {
+		return false, fmt.Errorf("share a: %w", err)
+	}
+	bReservedEnd, err := compactReservedBytesEnd(b)
+	if err != nil {
+		return false, fmt.Errorf("share b: %w", err)
+	}
+	if aReservedEnd != bReservedEnd {
+		// the shares differ in their sequence start indicator
+		return false, nil
+	}
+	reservedStart := aReservedEnd - appconsts.CompactShareReservedBytes
+	return bytes.Equal(a.data[:reservedStart], b.data[:reservedStart]) &&
+		bytes.Equal(a.data[aReservedEnd:], b.data[bReservedEnd:]), nil
+}
+
+// compactReservedBytesEnd returns the index in share of the first byte after
+// its reserved bytes. It returns an error if share is not a valid compact
+// share.
+func compactReservedBytesEnd(share *Share) (int, error) {
+	if err := share.Validate(); err != nil {
+		return 0, err
+	}
+	if !share.IsCompactShare() {
+		return 0, fmt.Errorf("share in namespace %x is not a compact share", share.NamespaceID())
+	}
+	return share.rawDataStartIndex()
+}
@@ -0,0 +1,193 @@
+package shares
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/nmt/namespace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamespaceLess(t *testing.T) {
+	nsTwo := namespace.ID{2, 2, 2, 2, 2, 2, 2, 2}
+	one := shareWithData(nsOne, true, 1, []byte{1})
+	two := shareWithData(nsTwo, true, 1, []byte{1})
+	tooShort := Share{data: []byte{1, 1, 1}}
+
+	type testCase struct {
+		name    string
+		a       Share
+		b       Share
+		want    bool
+		wantErr bool
+	}
+	testCases := []testCase{
+		{name: "a before b", a: one, b: two, want: true},
+		{name: "a after b", a: two, b: one, want: false},
+		{name: "same namespace", a: one, b: one, want: false},
+		{name: "a too short", a: tooShort, b: one, wantErr: true},
+		{name: "b too short", a: one, b: tooShort, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := NamespaceLess(&tc.a, &tc.b)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestNamespaceLessInsertionPoint(t *testing.T) {
+	nsTwo := namespace.ID{2, 2, 2, 2, 2, 2, 2, 2}
+	nsThree := namespace.ID{3, 3, 3, 3, 3, 3, 3, 3}
+	sorted := []Share{
+		shareWithData(nsOne, true, 1, []byte{1}),
+		shareWithData(nsThree, true, 1, []byte{1}),
+	}
+	share := shareWithData(nsTwo, true, 1, []byte{1})
+
+	got := sort.Search(len(sorted), func(i int) bool {
+		less, err := NamespaceLess(&share, &sorted[i])
+		require.NoError(t, err)
+		return less
+	})
+	assert.Equal(t, 1, got)
+}
+
+func TestSameNamespaceAndVersion(t *testing.T) {
+	nsTwo := namespace.ID{2, 2, 2, 2, 2, 2, 2, 2}
+	start := shareWithData(nsOne, true, 1, []byte{1})
+	continuation := shareWithData(nsOne, false, 0, []byte{1})
+	otherNamespace := shareWithData(nsTwo, true, 1, []byte{1})
+	// info byte with version 1 and the sequence start indicator set
+	otherVersion := Share{data: []byte{1, 1, 1, 1, 1, 1, 1, 1, 3}}
+	noInfoByte := Share{data: []byte{1, 1, 1, 1, 1, 1, 1, 1}}
+
+	type testCase struct {
+		name    string
+		a       Share
+		b       Share
+		want    bool
+		wantErr bool
+	}
+	testCases := []testCase{
+		{name: "same namespace and version", a: start, b: continuation, want: true},
+		{name: "different namespace", a: start, b: otherNamespace, want: false},
+		{name: "different version", a: start, b: otherVersion, want: false},
+		{name: "a has no info byte", a: noInfoByte, b: start, wantErr: true},
+		{name: "b has no info byte", a: start, b: noInfoByte, wantErr: true},
+		{name: "b too short", a: start, b: Share{data: []byte{1}}, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := SameNamespaceAndVersion(&tc.a, &tc.b)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestStableSortShares(t *testing.T) {
+	nsTwo := namespace.ID{2, 2, 2, 2, 2, 2, 2, 2}
+	twoStart := shareWithData(nsTwo, true, 600, []byte{1})
+	twoContinuation := shareWithData(nsTwo, false, 0, []byte{2})
+	oneFirstBlob := shareWithData(nsOne, true, 1, []byte{3})
+	oneSecondBlob := shareWithData(nsOne, true, 1, []byte{4})
+
+	shares := []Share{twoStart, oneFirstBlob, twoContinuation, oneSecondBlob}
+	err := StableSortShares(shares)
+	require.NoError(t, err)
+	assert.Equal(t, []Share{oneFirstBlob, oneSecondBlob, twoStart, twoContinuation}, shares)
+}
+
+func TestStableSortSharesTooShort(t *testing.T) {
+	nsTwo := namespace.ID{2, 2, 2, 2, 2, 2, 2, 2}
+	shares := []Share{
+		shareWithData(nsTwo, true, 1, []byte{1}),
+		shareWithData(nsOne, true, 1, []byte{1}),
+		{data: []byte{1}},
+	}
+	want := append([]Share{}, shares...)
+	err := StableSortShares(shares)
+	assert.Error(t, err)
+	assert.Equal(t, want, shares)
+}
+
+func TestShareByteDistance(t *testing.T) {
+	a := shareWithData(nsOne, true, 3, []byte{1, 2, 3})
+	b := shareWithData(nsOne, true, 3, []byte{1, 2, 4})
+	c := shareWithData(nsOne, true, 3, []byte{9, 9, 9})
+
+	type testCase struct {
+		name string
+		a, b Share
+		want int
+	}
+	testCases := []testCase{
+		{name: "identical shares", a: a, b: a, want: 0},
+		{name: "one differing byte", a: a, b: b, want: 1},
+		{name: "several differing bytes", a: a, b: c, want: 3},
+		{name: "different lengths", a: Share{data: []byte{1, 2, 3}}, b: Share{data: []byte{1}}, want: 2},
+		{name: "empty share", a: Share{}, b: Share{data: []byte{1, 2}}, want: 2},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, ShareByteDistance(&tc.a, &tc.b))
+			assert.Equal(t, tc.want, ShareByteDistance(&tc.b, &tc.a))
+		})
+	}
+}
+
+func TestCompactShareEqualIgnoringReserved(t *testing.T) {
+	units := []byte{1, 2, 3, 4}
+	withReserved := func(isStart bool, reserved []byte, data []byte) Share {
+		sequenceLen := uint32(0)
+		if isStart {
+			sequenceLen = uint32(len(data))
+		}
+		return shareWithData(appconsts.TxNamespaceID, isStart, sequenceLen, append(append([]byte{}, reserved...), data...))
+	}
+	original := withReserved(true, []byte{0, 0, 0, 17}, units)
+	reframed := withReserved(true, []byte{0, 0, 0, 0}, units)
+	different := withReserved(true, []byte{0, 0, 0, 17}, []byte{1, 2, 3, 5})
+	continuation := withReserved(false, []byte{0, 0, 0, 17}, units)
+	sparse := shareWithData(nsOne, true, 4, units)
+
+	type testCase struct {
+		name    string
+		a, b    Share
+		want    bool
+		wantErr bool
+	}
+	testCases := []testCase{
+		{name: "identical shares", a: original, b: original, want: true},
+		{name: "different reserved bytes", a: original, b: reframed, want: true},
+		{name: "different data", a: original, b: different, want: false},
+		{name: "start and continuation share", a: original, b: continuation, want: false},
+		{name: "sparse share", a: original, b: sparse, wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := CompactShareEqualIgnoringReserved(&tc.a, &tc.b)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
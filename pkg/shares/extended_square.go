@@ -0,0 +1,296 @@
+package shares
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/celestia-app/pkg/wrapper"
+	"github.com/celestiaorg/rsmt2d"
+)
+
+// ExtendSquare erasure codes the original data square of width squareSize
+// and returns the extended data square of width 2*squareSize. The original
+// shares are expected in row-major order. The top-left quadrant of the result
+// contains the original shares and the remaining three quadrants contain
+// parity shares in the parity namespace.
+func ExtendSquare(original []Share, squareSize int) ([][]Share, error) {
+	if err := ValidateSquareSize(squareSize); err != nil {
+		return nil, err
+	}
+	if len(original) != squareSize*squareSize {
+		return nil, fmt.Errorf("must provide %d shares for square size %d, got %d", squareSize*squareSize, squareSize, len(original))
+	}
+	for _, share := range original {
+		if err := share.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	eds, err := rsmt2d.ComputeExtendedDataSquare(ToBytes(original), appconsts.DefaultCodec(), wrapper.NewConstructor(uint64(squareSize)))
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([][][]byte, eds.Width())
+	for row := range rows {
+		rows[row] = eds.Row(uint(row))
+	}
+	return ExtendedSquareFromBytes(rows)
+}
+
+// ExtendedSquareFromBytes returns the shares of an extended data square whose
+// rows of share data are provided, e.g. as received from the network or read
+// from rsmt2d. Whether a share is a parity share isn't encoded in its bytes so
+// it is determined by the position of the share with IsParityPosition.
+func ExtendedSquareFromBytes(rows [][][]byte) ([][]Share, error) {
+	width := len(rows)
+	if width < 2 || width%2 != 0 {
+		return nil, fmt.Errorf("extended square width %d must be a positive even number", width)
+	}
+	squareSize := width / 2
+	extended := make([][]Share, width)
+	for row := range rows {
+		if len(rows[row]) != width {
+			return nil, fmt.Errorf("row %d of extended square of width %d must have %d shares, got %d", row, width, width, len(rows[row]))
+		}
+		extended[row] = make([]Share, width)
+		for col, cell := range rows[row] {
+			if !IsParityPosition(row, col, squareSize) {
+				extended[row][col] = Share{data: cell}
+				continue
+			}
+			share, err := NewParityShare(cell)
+			if err != nil {
+				return nil, err
+			}
+			extended[row][col] = share
+		}
+	}
+	return extended, nil
+}
+
+// IsParityPosition returns true if the share at row and col of an extended data
+// square with an original width of squareSize is in one of the parity
+// quadrants, i.e. outside the top-left quadrant that holds the original data
+// square.
+func IsParityPosition(row, col, squareSize int) bool {
+	return row >= squareSize || col >= squareSize
+}
+
+// NewParityShare returns a parity share that contains the erasure coded data
+// provided. Parity shares belong to the parity namespace
+// (appconsts.ParitySharesNamespaceID) but the namespace is not prepended to
+// data because the erasure coded data must remain unmodified for the extended
+// data square to be repaired and committed to.
+func NewParityShare(data []byte) (Share, error) {
+	if err := validateSize(data); err != nil {
+		return Share{}, err
+	}
+	return Share{data: data, isParity: true}, nil
+}
+
+// FromParityBytes returns parity shares that contain the erasure coded data
+// provided. Use it instead of FromBytes for data from the parity quadrants of
+// an extended data square because FromBytes can't tell parity data apart from
+// original data.
+func FromParityBytes(data [][]byte) ([]Share, error) {
+	shares := make([]Share, len(data))
+	for i, d := range data {
+		share, err := NewParityShare(d)
+		if err != nil {
+			return nil, fmt.Errorf("parity share %d: %w", i, err)
+		}
+		shares[i] = share
+	}
+	return shares, nil
+}
+
+// ExtractODS returns the original data square (the top-left quadrant) of an
+// extended data square of width extendedSize. It returns an error if the
+// square is not extendedSize by extendedSize or if ValidateParityPlacement
+// finds a misplaced share.
+func ExtractODS(extended [][]Share, extendedSize int) ([][]Share, error) {
+	if err := ValidateParityPlacement(extended, extendedSize); err != nil {
+		return nil, err
+	}
+
+	squareSize := extendedSize / 2
+	ods := make([][]Share, squareSize)
+	for row := range ods {
+		ods[row] = make([]Share, squareSize)
+		copy(ods[row], extended[row][:squareSize])
+	}
+	return ods, nil
+}
+
+// ValidateParityPlacement returns an error if a share of the extended data
+// square of width extendedSize is in the wrong quadrant. Every share in the
+// top-left quadrant (the original data square) must not be in the parity
+// namespace and every share in the other three quadrants must be a parity
+// share. The error identifies the row and column of the first misplaced
+// share. Use ValidateParityData to also verify the parity data itself.
+func ValidateParityPlacement(extended [][]Share, extendedSize int) error {
+	if err := validateExtendedSquareShape(extended, extendedSize); err != nil {
+		return err
+	}
+
+	squareSize := extendedSize / 2
+	for row := range extended {
+		for col, share := range extended[row] {
+			isParity := share.NamespaceID().Equal(appconsts.ParitySharesNamespaceID)
+			isParityPosition := IsParityPosition(row, col, squareSize)
+			if isParity && !isParityPosition {
+				return fmt.Errorf("share at row %d column %d of the original data square is a parity share", row, col)
+			}
+			if !isParity && isParityPosition {
+				return fmt.Errorf("share at row %d column %d of a parity quadrant is not a parity share", row, col)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateParityData returns an error if the shares in the parity quadrants of
+// the extended data square of width extendedSize don't match the erasure coding
+// of its original data square. Unlike ValidateParityPlacement, it re-encodes
+// the original data square so shares created from bytes (e.g. with FromBytes)
+// are verified by their data. The error identifies the row and column of the
+// first share that doesn't match.
+func ValidateParityData(extended [][]Share, extendedSize int) error {
+	if err := validateExtendedSquareShape(extended, extendedSize); err != nil {
+		return err
+	}
+
+	squareSize := extendedSize / 2
+	original := make([]Share, 0, squareSize*squareSize)
+	for row := 0; row < squareSize; row++ {
+		original = append(original, extended[row][:squareSize]...)
+	}
+	expected, err := ExtendSquare(original, squareSize)
+	if err != nil {
+		return err
+	}
+	for row := range extended {
+		for col, share := range extended[row] {
+			if !IsParityPosition(row, col, squareSize) {
+				continue
+			}
+			if !bytes.Equal(share.data, expected[row][col].data) {
+				return fmt.Errorf("share at row %d column %d of a parity quadrant is not parity data of the original data square", row, col)
+			}
+		}
+	}
+	return nil
+}
+
+// validateExtendedSquareShape returns an error if extended is not an
+// extendedSize by extendedSize square or if extendedSize is not twice a valid
+// square size.
+func validateExtendedSquareShape(extended [][]Share, extendedSize int) error {
+	if extendedSize < 2 || extendedSize%2 != 0 {
+		return fmt.Errorf("extended square size %d must be a positive even number", extendedSize)
+	}
+	if err := ValidateSquareSize(extendedSize / 2); err != nil {
+		return err
+	}
+	if len(extended) != extendedSize {
+		return fmt.Errorf("extended square of width %d must have %d rows, got %d", extendedSize, extendedSize, len(extended))
+	}
+	for row := range extended {
+		if len(extended[row]) != extendedSize {
+			return fmt.Errorf("row %d of extended square of width %d must have %d shares, got %d", row, extendedSize, extendedSize, len(extended[row]))
+		}
+	}
+	return nil
+}
+
+// RecoverShareInRow recovers the share at missingIndex of the row at rowIndex
+// of an extended data square with an original width of squareSize. Every other
+// share in row must be present because the missing share is recovered by
+// Reed-Solomon decoding the row. If the recovered share belongs to the original
+// data square, it returns an error if its namespace is the parity namespace or
+// isn't between the namespaces of the original shares on either side of it.
+func RecoverShareInRow(row []*Share, rowIndex, missingIndex, squareSize int) (*Share, error) {
+	if err := ValidateSquareSize(squareSize); err != nil {
+		return nil, err
+	}
+	if rowIndex < 0 || rowIndex >= 2*squareSize {
+		return nil, fmt.Errorf("row index %d is out of range for an extended square with an original width of %d", rowIndex, squareSize)
+	}
+	if len(row) != 2*squareSize {
+		return nil, fmt.Errorf("row of an extended square with an original width of %d must have %d shares, got %d", squareSize, 2*squareSize, len(row))
+	}
+	if missingIndex < 0 || missingIndex >= len(row) {
+		return nil, fmt.Errorf("missing index %d is out of range for a row of %d shares", missingIndex, len(row))
+	}
+
+	for i, share := range row {
+		if i != missingIndex && share == nil {
+			return nil, fmt.Errorf("share %d is missing but only share %d may be missing", i, missingIndex)
+		}
+	}
+	row = append([]*Share{}, row...)
+	row[missingIndex] = nil
+
+	codec := appconsts.DefaultCodec()
+	original, err := decodeRow(codec, row, squareSize)
+	if err != nil {
+		return nil, err
+	}
+	if IsParityPosition(rowIndex, missingIndex, squareSize) {
+		data := original[missingIndex%squareSize]
+		if missingIndex >= squareSize {
+			parity, err := codec.Encode(original)
+			if err != nil {
+				return nil, err
+			}
+			data = parity[missingIndex-squareSize]
+		}
+		share, err := NewParityShare(data)
+		return &share, err
+	}
+
+	share := Share{data: original[missingIndex]}
+	if err := share.Validate(); err != nil {
+		return nil, err
+	}
+	ns := share.NamespaceID()
+	if ns.Equal(appconsts.ParitySharesNamespaceID) {
+		return nil, fmt.Errorf("recovered share %d of the original data square is in the parity namespace", missingIndex)
+	}
+	if missingIndex > 0 && bytes.Compare(ns, row[missingIndex-1].NamespaceID()) < 0 {
+		return nil, fmt.Errorf("recovered share %d has namespace %x which is less than the namespace of the previous share %x", missingIndex, ns, row[missingIndex-1].NamespaceID())
+	}
+	if missingIndex < squareSize-1 && bytes.Compare(ns, row[missingIndex+1].NamespaceID()) > 0 {
+		return nil, fmt.Errorf("recovered share %d has namespace %x which is greater than the namespace of the next share %x", missingIndex, ns, row[missingIndex+1].NamespaceID())
+	}
+	return &share, nil
+}
+
+// decodeRow Reed-Solomon decodes a row of an extended data square with an
+// original width of squareSize and returns the data of the shares in the
+// original half of the row. Missing shares in row must be nil and at least
+// squareSize shares must be present.
+func decodeRow(codec rsmt2d.Codec, row []*Share, squareSize int) ([][]byte, error) {
+	data := make([][]byte, len(row))
+	present := 0
+	for i, share := range row {
+		if share == nil {
+			continue
+		}
+		if err := share.Validate(); err != nil {
+			return nil, err
+		}
+		data[i] = share.data
+		present++
+	}
+	if present < squareSize {
+		return nil, fmt.Errorf("row has %d shares but at least %d are needed to recover the missing shares", present, squareSize)
+	}
+	decoded, err := codec.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	return decoded[:squareSize], nil
+}
@@ -0,0 +1,313 @@
+package shares
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/celestia-app/pkg/wrapper"
+	"github.com/celestiaorg/nmt/namespace"
+	"github.com/celestiaorg/rsmt2d"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtendSquare(t *testing.T) {
+	squareSize := 4
+	original, err := TailPaddingShares(squareSize * squareSize)
+	require.NoError(t, err)
+	original[0] = shareWithData(nsOne, true, 3, []byte{1, 2, 3})
+
+	extended, err := ExtendSquare(original, squareSize)
+	require.NoError(t, err)
+	require.Len(t, extended, 2*squareSize)
+
+	for row := range extended {
+		require.Len(t, extended[row], 2*squareSize)
+		for col, share := range extended[row] {
+			if row < squareSize && col < squareSize {
+				assert.Equal(t, original[row*squareSize+col].ToBytes(), share.ToBytes())
+				assert.False(t, share.IsParityShare())
+				continue
+			}
+			assert.True(t, share.IsParityShare())
+			assert.Equal(t, appconsts.ParitySharesNamespaceID, share.NamespaceID())
+		}
+	}
+
+	// the extended square should match the one used for the data availability header
+	eds, err := rsmt2d.ComputeExtendedDataSquare(ToBytes(original), appconsts.DefaultCodec(), wrapper.NewConstructor(uint64(squareSize)))
+	require.NoError(t, err)
+	for row := range extended {
+		assert.Equal(t, eds.Row(uint(row)), ToBytes(extended[row]))
+	}
+}
+
+func TestExtendSquareErrors(t *testing.T) {
+	original, err := TailPaddingShares(4)
+	require.NoError(t, err)
+
+	_, err = ExtendSquare(original, 3)
+	assert.Error(t, err)
+
+	_, err = ExtendSquare(original, 4)
+	assert.Error(t, err)
+
+	_, err = ExtendSquare(original[:3], 2)
+	assert.Error(t, err)
+}
+
+func TestNewParityShare(t *testing.T) {
+	data := bytes.Repeat([]byte{0xFF}, appconsts.ShareSize)
+	share, err := NewParityShare(data)
+	require.NoError(t, err)
+	assert.True(t, share.IsParityShare())
+	assert.Equal(t, appconsts.ParitySharesNamespaceID, share.NamespaceID())
+	assert.Equal(t, data, share.ToBytes())
+
+	// the erasure coded data of a parity share must not be read as a header
+	_, err = share.InfoByte()
+	assert.Error(t, err)
+	_, err = share.IsSequenceStart()
+	assert.Error(t, err)
+	_, err = share.SequenceLen()
+	assert.Error(t, err)
+	_, err = share.RawData()
+	assert.Error(t, err)
+	_, err = share.Header()
+	assert.Error(t, err)
+
+	_, err = NewParityShare(data[:appconsts.ShareSize-1])
+	assert.Error(t, err)
+}
+
+func TestExtendedSquareFromBytes(t *testing.T) {
+	squareSize := 2
+	original, err := TailPaddingShares(squareSize * squareSize)
+	require.NoError(t, err)
+	original[0] = shareWithData(nsOne, true, 3, []byte{1, 2, 3})
+	extended, err := ExtendSquare(original, squareSize)
+	require.NoError(t, err)
+
+	rows := make([][][]byte, len(extended))
+	for row := range extended {
+		rows[row] = ToBytes(extended[row])
+	}
+	got, err := ExtendedSquareFromBytes(rows)
+	require.NoError(t, err)
+	assert.Equal(t, extended, got)
+	for row := range got {
+		for col := range got[row] {
+			assert.Equal(t, IsParityPosition(row, col, squareSize), got[row][col].IsParityShare())
+		}
+	}
+
+	_, err = ExtendedSquareFromBytes(rows[:3])
+	assert.Error(t, err)
+	_, err = ExtendedSquareFromBytes([][][]byte{rows[0][:3], rows[1][:3]})
+	assert.Error(t, err)
+}
+
+func TestFromParityBytes(t *testing.T) {
+	data := [][]byte{bytes.Repeat([]byte{0xAB}, appconsts.ShareSize), bytes.Repeat([]byte{0x01}, appconsts.ShareSize)}
+	shares, err := FromParityBytes(data)
+	require.NoError(t, err)
+	require.Len(t, shares, len(data))
+	for i, share := range shares {
+		assert.True(t, share.IsParityShare())
+		assert.Equal(t, appconsts.ParitySharesNamespaceID, share.NamespaceID())
+		assert.Equal(t, data[i], share.ToBytes())
+	}
+
+	// FromBytes can't tell that the data is parity data
+	assert.False(t, FromBytes(data)[0].IsParityShare())
+
+	_, err = FromParityBytes([][]byte{data[0][:appconsts.ShareSize-1]})
+	assert.Error(t, err)
+}
+
+func TestExtractODS(t *testing.T) {
+	squareSize := 2
+	original, err := TailPaddingShares(squareSize * squareSize)
+	require.NoError(t, err)
+	original[0] = shareWithData(nsOne, true, 3, []byte{1, 2, 3})
+	extended, err := ExtendSquare(original, squareSize)
+	require.NoError(t, err)
+
+	ods, err := ExtractODS(extended, 2*squareSize)
+	require.NoError(t, err)
+	require.Len(t, ods, squareSize)
+	for row := range ods {
+		assert.Equal(t, original[row*squareSize:(row+1)*squareSize], ods[row])
+	}
+
+	type testCase struct {
+		name         string
+		extended     [][]Share
+		extendedSize int
+	}
+	withParityInODS := copyExtendedSquare(extended)
+	withParityInODS[1][1] = extended[3][3]
+	withDataInParity := copyExtendedSquare(extended)
+	withDataInParity[0][3] = original[0]
+	withShortRow := copyExtendedSquare(extended)
+	withShortRow[2] = withShortRow[2][:3]
+
+	testCases := []testCase{
+		{name: "parity share in the original data square", extended: withParityInODS, extendedSize: 4},
+		{name: "data share in a parity quadrant", extended: withDataInParity, extendedSize: 4},
+		{name: "short row", extended: withShortRow, extendedSize: 4},
+		{name: "wrong number of rows", extended: extended[:3], extendedSize: 4},
+		{name: "invalid extended size", extended: extended, extendedSize: 3},
+		{name: "extended size of one", extended: [][]Share{{original[0]}}, extendedSize: 1},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ExtractODS(tc.extended, tc.extendedSize)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestValidateParityPlacement(t *testing.T) {
+	squareSize := 2
+	original, err := TailPaddingShares(squareSize * squareSize)
+	require.NoError(t, err)
+	original[0] = shareWithData(nsOne, true, 3, []byte{1, 2, 3})
+	extended, err := ExtendSquare(original, squareSize)
+	require.NoError(t, err)
+	assert.NoError(t, ValidateParityPlacement(extended, 2*squareSize))
+
+	type testCase struct {
+		name         string
+		extended     [][]Share
+		extendedSize int
+		wantErr      string
+	}
+	withParityInODS := copyExtendedSquare(extended)
+	withParityInODS[1][0] = extended[2][2]
+	withDataInParity := copyExtendedSquare(extended)
+	withDataInParity[3][1] = original[0]
+	// shares created from bytes aren't parity shares
+	fromBytes := make([][]Share, len(extended))
+	for row := range extended {
+		fromBytes[row] = FromBytes(ToBytes(extended[row]))
+	}
+
+	testCases := []testCase{
+		{name: "parity share in the original data square", extended: withParityInODS, extendedSize: 4, wantErr: "row 1 column 0"},
+		{name: "data share in a parity quadrant", extended: withDataInParity, extendedSize: 4, wantErr: "row 3 column 1"},
+		{name: "shares created from bytes", extended: fromBytes, extendedSize: 4, wantErr: "row 0 column 2"},
+		{name: "wrong number of rows", extended: extended[:3], extendedSize: 4, wantErr: "rows"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateParityPlacement(tc.extended, tc.extendedSize)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.wantErr)
+		})
+	}
+}
+
+func TestValidateParityData(t *testing.T) {
+	squareSize := 2
+	original, err := TailPaddingShares(squareSize * squareSize)
+	require.NoError(t, err)
+	original[0] = shareWithData(nsOne, true, 3, []byte{1, 2, 3})
+	extended, err := ExtendSquare(original, squareSize)
+	require.NoError(t, err)
+	assert.NoError(t, ValidateParityData(extended, 2*squareSize))
+
+	// shares created from bytes aren't parity shares but their data is still
+	// the parity data of the original data square
+	fromBytes := make([][]Share, len(extended))
+	for row := range extended {
+		fromBytes[row] = FromBytes(ToBytes(extended[row]))
+	}
+	assert.NoError(t, ValidateParityData(fromBytes, 2*squareSize))
+
+	withWrongParity := copyExtendedSquare(extended)
+	withWrongParity[2][3] = extended[2][2]
+	err = ValidateParityData(withWrongParity, 2*squareSize)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "row 2 column 3")
+
+	assert.Error(t, ValidateParityData(extended[:3], 2*squareSize))
+}
+
+func TestRecoverShareInRow(t *testing.T) {
+	squareSize := 2
+	original, err := TailPaddingShares(squareSize * squareSize)
+	require.NoError(t, err)
+	original[0] = shareWithData(nsOne, true, 3, []byte{1, 2, 3})
+	extended, err := ExtendSquare(original, squareSize)
+	require.NoError(t, err)
+
+	// rowWithout returns pointers to the shares of row in extended with the
+	// share at missingIndex removed
+	rowWithout := func(row, missingIndex int) []*Share {
+		shares := make([]*Share, len(extended[row]))
+		for i := range extended[row] {
+			if i != missingIndex {
+				shares[i] = &extended[row][i]
+			}
+		}
+		return shares
+	}
+
+	for row := range extended {
+		for missingIndex := range extended[row] {
+			got, err := RecoverShareInRow(rowWithout(row, missingIndex), row, missingIndex, squareSize)
+			require.NoError(t, err)
+			assert.Equal(t, extended[row][missingIndex], *got, "row %d index %d", row, missingIndex)
+		}
+	}
+
+	t.Run("more than one missing share", func(t *testing.T) {
+		shares := rowWithout(0, 0)
+		shares[2] = nil
+		_, err := RecoverShareInRow(shares, 0, 0, squareSize)
+		assert.Error(t, err)
+	})
+	t.Run("recovered share out of namespace order", func(t *testing.T) {
+		unsorted := append([]Share{}, original...)
+		unsorted[0] = shareWithData(namespace.ID{2, 2, 2, 2, 2, 2, 2, 2}, true, 1, []byte{1})
+		unsorted[1] = shareWithData(nsOne, true, 1, []byte{1})
+		unsortedExtended, err := ExtendSquare(unsorted, squareSize)
+		require.NoError(t, err)
+		shares := []*Share{&unsortedExtended[0][0], nil, &unsortedExtended[0][2], &unsortedExtended[0][3]}
+		_, err = RecoverShareInRow(shares, 0, 1, squareSize)
+		assert.Error(t, err)
+	})
+	t.Run("wrong row length", func(t *testing.T) {
+		_, err := RecoverShareInRow(rowWithout(0, 0)[:3], 0, 0, squareSize)
+		assert.Error(t, err)
+	})
+	t.Run("missing index out of range", func(t *testing.T) {
+		_, err := RecoverShareInRow(rowWithout(0, 0), 0, 4, squareSize)
+		assert.Error(t, err)
+	})
+	t.Run("row index out of range", func(t *testing.T) {
+		_, err := RecoverShareInRow(rowWithout(0, 0), 4, 0, squareSize)
+		assert.Error(t, err)
+	})
+	t.Run("parity row created from bytes", func(t *testing.T) {
+		// shares of a parity row created with FromBytes aren't marked as parity
+		// shares but the recovered share is still a parity share
+		fromBytes := FromBytes(ToBytes(extended[2]))
+		shares := []*Share{nil, &fromBytes[1], &fromBytes[2], &fromBytes[3]}
+		got, err := RecoverShareInRow(shares, 2, 0, squareSize)
+		require.NoError(t, err)
+		assert.Equal(t, extended[2][0], *got)
+	})
+}
+
+// copyExtendedSquare returns a copy of extended so that a test can corrupt
+// its own square without affecting extended.
+func copyExtendedSquare(extended [][]Share) [][]Share {
+	copied := make([][]Share, len(extended))
+	for row := range extended {
+		copied[row] = append([]Share{}, extended[row]...)
+	}
+	return copied
+}
@@ -0,0 +1,60 @@
+package shares
+
+import (
+	"fmt"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+)
+
+// IndexedShare is a share along with its index in the original data square.
+type IndexedShare struct {
+	Index int
+	Share
+}
+
+// MissingIndicesError is returned by SortByIndex if the indexed shares provided
+// don't cover every index of the square.
+type MissingIndicesError struct {
+	// Indices are the missing indices in ascending order.
+	Indices []int
+}
+
+func (e *MissingIndicesError) Error() string {
+	return fmt.Sprintf("missing shares at indices %v", e.Indices)
+}
+
+// SortByIndex places the indexed shares provided into a slice of totalShares
+// shares ordered by index. It returns an error if totalShares exceeds the
+// maximum number of shares in a data square, an index is not in the range
+// [0, totalShares), or two shares have the same index. It returns a
+// *MissingIndicesError that lists every missing index if the shares provided
+// don't cover every index below totalShares.
+func SortByIndex(indexed []IndexedShare, totalShares int) ([]Share, error) {
+	if totalShares < 0 || totalShares > appconsts.MaxShareCount {
+		return nil, fmt.Errorf("total shares %d is out of range [0, %d]", totalShares, appconsts.MaxShareCount)
+	}
+
+	shares := make([]Share, totalShares)
+	present := make([]bool, totalShares)
+	for _, is := range indexed {
+		if is.Index < 0 || is.Index >= totalShares {
+			return nil, fmt.Errorf("share index %d is out of range [0, %d)", is.Index, totalShares)
+		}
+		if present[is.Index] {
+			return nil, fmt.Errorf("duplicate share at index %d", is.Index)
+		}
+		present[is.Index] = true
+		shares[is.Index] = is.Share
+	}
+
+	var missing []int
+	for i, ok := range present {
+		if !ok {
+			missing = append(missing, i)
+		}
+	}
+	if len(missing) != 0 {
+		return nil, &MissingIndicesError{Indices: missing}
+	}
+	return shares, nil
+}
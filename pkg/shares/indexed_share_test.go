@@ -0,0 +1,109 @@
+package shares
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortByIndex(t *testing.T) {
+	zero := shareWithData(nsOne, true, 1, []byte{0})
+	one := shareWithData(nsOne, true, 1, []byte{1})
+	two := shareWithData(nsOne, true, 1, []byte{2})
+
+	type testCase struct {
+		name        string
+		indexed     []IndexedShare
+		totalShares int
+		want        []Share
+		wantErr     bool
+		wantMissing []int
+	}
+	testCases := []testCase{
+		{
+			name:        "no shares",
+			indexed:     nil,
+			totalShares: 0,
+			want:        []Share{},
+		},
+		{
+			name:        "shuffled shares",
+			indexed:     []IndexedShare{{2, two}, {0, zero}, {1, one}},
+			totalShares: 3,
+			want:        []Share{zero, one, two},
+		},
+		{
+			name:        "duplicate index",
+			indexed:     []IndexedShare{{0, zero}, {0, one}},
+			totalShares: 2,
+			wantErr:     true,
+		},
+		{
+			name:        "negative index",
+			indexed:     []IndexedShare{{-1, zero}},
+			totalShares: 1,
+			wantErr:     true,
+		},
+		{
+			name:        "index exceeds total shares",
+			indexed:     []IndexedShare{{0, zero}, {1, one}},
+			totalShares: 1,
+			wantErr:     true,
+		},
+		{
+			name:        "total shares exceeds max share count",
+			indexed:     nil,
+			totalShares: appconsts.MaxShareCount + 1,
+			wantErr:     true,
+		},
+		{
+			name:        "negative total shares",
+			indexed:     nil,
+			totalShares: -1,
+			wantErr:     true,
+		},
+		{
+			name:        "missing indices",
+			indexed:     []IndexedShare{{3, two}, {1, one}},
+			totalShares: 4,
+			wantErr:     true,
+			wantMissing: []int{0, 2},
+		},
+		{
+			name:        "missing trailing indices",
+			indexed:     []IndexedShare{{1, one}, {0, zero}},
+			totalShares: 4,
+			wantErr:     true,
+			wantMissing: []int{2, 3},
+		},
+		{
+			name:        "no shares of a non-empty square",
+			indexed:     nil,
+			totalShares: 2,
+			wantErr:     true,
+			wantMissing: []int{0, 1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := SortByIndex(tc.indexed, tc.totalShares)
+			if tc.wantErr {
+				require.Error(t, err)
+				var missingErr *MissingIndicesError
+				if tc.wantMissing != nil {
+					require.True(t, errors.As(err, &missingErr))
+					assert.Equal(t, tc.wantMissing, missingErr.Indices)
+				} else {
+					assert.False(t, errors.As(err, &missingErr))
+				}
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -6,6 +6,22 @@ import (
 	"github.com/celestiaorg/celestia-app/pkg/appconsts"
 )
 
+// The info byte is laid out as follows (most significant bit first):
+//
+//	| 7 6 5 4 3 2 1 | 0                        |
+//	| share version | sequence start indicator |
+const (
+	// ShareVersionMask is the bits of the info byte that contain the share
+	// version.
+	ShareVersionMask = 0xFE
+	// ShareVersionShift is the number of bits the share version is shifted
+	// left by in the info byte.
+	ShareVersionShift = 1
+	// SequenceStartBit is the bit of the info byte that is set if the share is
+	// the first share of a sequence.
+	SequenceStartBit = 0x01
+)
+
 // InfoByte is a byte with the following structure: the first 7 bits are
 // reserved for version information in big endian form (initially `0000000`).
 // The last bit is a "sequence start indicator", that is `1` if this is the
@@ -17,9 +33,9 @@ func NewInfoByte(version uint8, isSequenceStart bool) (InfoByte, error) {
 		return 0, fmt.Errorf("version %d must be less than or equal to %d", version, appconsts.MaxShareVersion)
 	}
 
-	prefix := version << 1
+	prefix := version << ShareVersionShift
 	if isSequenceStart {
-		return InfoByte(prefix + 1), nil
+		return InfoByte(prefix | SequenceStartBit), nil
 	}
 	return InfoByte(prefix), nil
 }
@@ -27,17 +43,38 @@ func NewInfoByte(version uint8, isSequenceStart bool) (InfoByte, error) {
 // Version returns the version encoded in this InfoByte. Version is
 // expected to be between 0 and appconsts.MaxShareVersion (inclusive).
 func (i InfoByte) Version() uint8 {
-	version := uint8(i) >> 1
+	version := uint8(i) >> ShareVersionShift
 	return version
 }
 
 // IsSequenceStart returns whether this share is the start of a sequence.
 func (i InfoByte) IsSequenceStart() bool {
-	return uint(i)%2 == 1
+	return i&SequenceStartBit != 0
+}
+
+// IsSequenceStartByte returns whether the raw info byte i has the sequence
+// start indicator set. Unlike ParseInfoByte, it doesn't parse the share
+// version.
+func IsSequenceStartByte(i byte) bool {
+	return i&SequenceStartBit != 0
 }
 
 func ParseInfoByte(i byte) (InfoByte, error) {
-	isSequenceStart := i%2 == 1
-	version := uint8(i) >> 1
+	isSequenceStart := i&SequenceStartBit != 0
+	version := (i & ShareVersionMask) >> ShareVersionShift
 	return NewInfoByte(version, isSequenceStart)
 }
+
+// ReplaceInfoByte returns a copy of the share data provided with the info byte
+// replaced by newInfoByte. It returns an error if data is not the size of a
+// share. The data provided is not modified.
+func ReplaceInfoByte(data []byte, newInfoByte byte) ([]byte, error) {
+	if err := validateSize(data); err != nil {
+		return nil, err
+	}
+	replaced := make([]byte, len(data))
+	copy(replaced, data)
+	// the info byte is the first byte after the namespace ID
+	replaced[appconsts.NamespaceSize] = newInfoByte
+	return replaced, nil
+}
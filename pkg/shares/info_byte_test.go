@@ -1,6 +1,11 @@
 package shares
 
-import "testing"
+import (
+	"bytes"
+	"testing"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+)
 
 func TestInfoByte(t *testing.T) {
 	blobStart := true
@@ -101,3 +106,66 @@ func TestParseInfoByte(t *testing.T) {
 		}
 	}
 }
+
+func TestReplaceInfoByte(t *testing.T) {
+	share := shareWithData(nsOne, true, 1, []byte{1})
+	data := share.ToBytes()
+
+	got, err := ReplaceInfoByte(data, 0b00000010)
+	if err != nil {
+		t.Fatalf("got %v want no error", err)
+	}
+	replaced := Share{data: got}
+	infoByte, err := replaced.InfoByte()
+	if err != nil {
+		t.Fatalf("got %v want no error", err)
+	}
+	if infoByte.Version() != 1 || infoByte.IsSequenceStart() {
+		t.Errorf("got version %v and IsSequenceStart %v want version 1 and IsSequenceStart false", infoByte.Version(), infoByte.IsSequenceStart())
+	}
+	if data[appconsts.NamespaceSize] != 0b00000001 {
+		t.Errorf("ReplaceInfoByte modified the data provided")
+	}
+	if !bytes.Equal(got[:appconsts.NamespaceSize], data[:appconsts.NamespaceSize]) || !bytes.Equal(got[appconsts.NamespaceSize+1:], data[appconsts.NamespaceSize+1:]) {
+		t.Errorf("ReplaceInfoByte modified bytes other than the info byte")
+	}
+
+	if _, err := ReplaceInfoByte(data[:appconsts.ShareSize-1], 0); err == nil {
+		t.Errorf("got no error want error for data that is too short")
+	}
+}
+
+func TestInfoByteLayout(t *testing.T) {
+	if ShareVersionMask&SequenceStartBit != 0 {
+		t.Errorf("ShareVersionMask %08b overlaps SequenceStartBit %08b", ShareVersionMask, SequenceStartBit)
+	}
+	if ShareVersionMask|SequenceStartBit != 0xFF {
+		t.Errorf("ShareVersionMask %08b and SequenceStartBit %08b don't cover the info byte", ShareVersionMask, SequenceStartBit)
+	}
+	if got := uint8(ShareVersionMask >> ShareVersionShift); got != appconsts.MaxShareVersion {
+		t.Errorf("got max version %v from ShareVersionMask want %v", got, appconsts.MaxShareVersion)
+	}
+
+	infoByte, err := NewInfoByte(3, true)
+	if err != nil {
+		t.Fatalf("got %v want no error", err)
+	}
+	if byte(infoByte)&SequenceStartBit == 0 {
+		t.Errorf("got info byte %08b without SequenceStartBit set", infoByte)
+	}
+	if got := (byte(infoByte) & ShareVersionMask) >> ShareVersionShift; got != 3 {
+		t.Errorf("got version %v want 3", got)
+	}
+}
+
+func TestIsSequenceStartByte(t *testing.T) {
+	for i := 0; i <= 0xFF; i++ {
+		infoByte, err := ParseInfoByte(byte(i))
+		if err != nil {
+			t.Fatalf("got %v want no error", err)
+		}
+		if IsSequenceStartByte(byte(i)) != infoByte.IsSequenceStart() {
+			t.Errorf("got IsSequenceStartByte %v want %v for info byte %08b", IsSequenceStartByte(byte(i)), infoByte.IsSequenceStart(), i)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package shares
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/celestiaorg/nmt/namespace"
+)
+
+// BlobNamespaceRanges returns a map from the hex encoded namespace ID of each
+// blob namespace in shares to the range [start, end) of share indexes that the
+// blobs in that namespace occupy. Compact shares and padding shares are
+// skipped. If a namespace contains multiple blobs, the range spans from the
+// first share of the first blob to the last share of the last blob. It returns
+// an error if the shares of a namespace are not contiguous.
+func BlobNamespaceRanges(shares []Share) (map[string][2]int, error) {
+	ranges := make(map[string][2]int)
+	lastKey := ""
+	for i, share := range shares {
+		if err := share.Validate(); err != nil {
+			return nil, err
+		}
+		if share.IsCompactShare() {
+			continue
+		}
+		isPadding, err := share.IsPadding()
+		if err != nil {
+			return nil, err
+		}
+		if isPadding {
+			continue
+		}
+
+		key := hex.EncodeToString(share.NamespaceID())
+		r, ok := ranges[key]
+		if ok && key != lastKey {
+			return nil, fmt.Errorf("shares of namespace %s are not contiguous: share %d follows shares of namespace %s", key, i, lastKey)
+		}
+		if !ok {
+			r[0] = i
+		}
+		r[1] = i + 1
+		ranges[key] = r
+		lastKey = key
+	}
+	return ranges, nil
+}
+
+// ForEachNamespaceWithBlobCount calls fn once per blob namespace in shares, in
+// the order the namespaces appear, with the number of blobs (sequence starts)
+// in that namespace. Compact shares and padding shares are skipped. shares must
+// be sorted by namespace so it returns an error if a namespace is smaller than
+// the namespace before it. If fn returns an error, iteration stops and the
+// error is returned.
+func ForEachNamespaceWithBlobCount(shares []Share, fn func(ns namespace.ID, blobCount int) error) error {
+	var current namespace.ID
+	blobCount := 0
+	for i, share := range shares {
+		if err := share.Validate(); err != nil {
+			return err
+		}
+		if share.IsCompactShare() {
+			continue
+		}
+		isPadding, err := share.IsPadding()
+		if err != nil {
+			return err
+		}
+		if isPadding {
+			continue
+		}
+
+		ns := share.NamespaceID()
+		if current != nil && !ns.Equal(current) {
+			if bytes.Compare(ns, current) < 0 {
+				return fmt.Errorf("shares are not sorted by namespace: share %d has namespace %x which is less than namespace %x", i, ns, current)
+			}
+			if err := fn(current, blobCount); err != nil {
+				return err
+			}
+			blobCount = 0
+		}
+		current = ns
+
+		isStart, err := share.IsSequenceStart()
+		if err != nil {
+			return err
+		}
+		if isStart {
+			blobCount++
+		}
+	}
+	if current == nil {
+		return nil
+	}
+	return fn(current, blobCount)
+}
+
+// NamespaceRange is an inclusive range of namespace IDs.
+type NamespaceRange struct {
+	Min namespace.ID
+	Max namespace.ID
+}
+
+// Contains returns true if the namespace ID of share s is within the range
+// [r.Min, r.Max].
+func (r NamespaceRange) Contains(s *Share) (bool, error) {
+	ns, err := s.namespaceIDOrErr()
+	if err != nil {
+		return false, err
+	}
+	return bytes.Compare(ns, r.Min) >= 0 && bytes.Compare(ns, r.Max) <= 0, nil
+}
+
+// SharesInRange returns the shares whose namespace ID is within the range r.
+// It returns an error if r.Min is greater than r.Max.
+func SharesInRange(shares []Share, r NamespaceRange) ([]Share, error) {
+	if bytes.Compare(r.Min, r.Max) > 0 {
+		return nil, fmt.Errorf("namespace range minimum %x is greater than the maximum %x", r.Min, r.Max)
+	}
+	inRange := []Share{}
+	for i := range shares {
+		contains, err := r.Contains(&shares[i])
+		if err != nil {
+			return nil, err
+		}
+		if contains {
+			inRange = append(inRange, shares[i])
+		}
+	}
+	return inRange, nil
+}
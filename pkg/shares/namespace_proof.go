@@ -0,0 +1,53 @@
+package shares
+
+import (
+	"errors"
+	"fmt"
+	"hash"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/nmt"
+	"github.com/celestiaorg/nmt/namespace"
+)
+
+// ProveNamespaceAbsence builds a namespaced Merkle tree over shares and returns
+// a proof that no share in the tree belongs to ns along with the root of the
+// tree. If ns falls between the namespaces of two shares, the proof contains
+// the leaf hash of the share where ns would be. If ns is outside the range of
+// namespaces in the tree, the proof is an empty range proof. It returns an
+// error if any share belongs to ns. hasher is the base hash function used to
+// construct the tree (e.g. appconsts.NewBaseHashFunc()).
+func ProveNamespaceAbsence(shares []Share, ns namespace.ID, hasher hash.Hash) (nmt.Proof, []byte, error) {
+	if len(shares) == 0 {
+		return nmt.Proof{}, nil, errors.New("cannot prove namespace absence in zero shares")
+	}
+	if len(ns) != appconsts.NamespaceSize {
+		return nmt.Proof{}, nil, fmt.Errorf("namespace ID %x must be %d bytes", ns, appconsts.NamespaceSize)
+	}
+
+	tree := nmt.New(hasher, nmt.NamespaceIDSize(appconsts.NamespaceSize))
+	for i, share := range shares {
+		if err := share.Validate(); err != nil {
+			return nmt.Proof{}, nil, err
+		}
+		if share.NamespaceID().Equal(ns) {
+			return nmt.Proof{}, nil, fmt.Errorf("share %d belongs to namespace %x", i, ns)
+		}
+		if err := tree.Push(namespacedLeaf(share)); err != nil {
+			return nmt.Proof{}, nil, err
+		}
+	}
+
+	proof, err := tree.ProveNamespace(ns)
+	if err != nil {
+		return nmt.Proof{}, nil, err
+	}
+	return proof, tree.Root(), nil
+}
+
+// namespacedLeaf returns the leaf that share contributes to a namespaced Merkle
+// tree: the namespace of the share followed by the share.
+func namespacedLeaf(share Share) []byte {
+	leaf := make([]byte, 0, appconsts.NamespaceSize+len(share.data))
+	return append(append(leaf, share.NamespaceID()...), share.data...)
+}
@@ -0,0 +1,62 @@
+package shares
+
+import (
+	"testing"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/nmt/namespace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProveNamespaceAbsence(t *testing.T) {
+	nsTwo := namespace.ID{0, 0, 0, 0, 0, 0, 0, 2}
+	nsThree := namespace.ID{0, 0, 0, 0, 0, 0, 0, 3}
+	nsFive := namespace.ID{0, 0, 0, 0, 0, 0, 0, 5}
+	shares := []Share{
+		shareWithData(nsTwo, true, 1, []byte{1}),
+		shareWithData(nsTwo, true, 1, []byte{2}),
+		shareWithData(nsFive, true, 1, []byte{3}),
+	}
+
+	type testCase struct {
+		name string
+		ns   namespace.ID
+	}
+	testCases := []testCase{
+		{name: "namespace between two shares", ns: nsThree},
+		{name: "namespace before all shares", ns: nsOne},
+		{name: "namespace after all shares", ns: namespace.ID{0, 0, 0, 0, 0, 0, 0, 9}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			proof, root, err := ProveNamespaceAbsence(shares, tc.ns, appconsts.NewBaseHashFunc())
+			require.NoError(t, err)
+			assert.True(t, proof.VerifyNamespace(appconsts.NewBaseHashFunc(), tc.ns, nil, root))
+		})
+	}
+
+	t.Run("absence proof contains the leaf where the namespace would be", func(t *testing.T) {
+		proof, _, err := ProveNamespaceAbsence(shares, nsThree, appconsts.NewBaseHashFunc())
+		require.NoError(t, err)
+		assert.True(t, proof.IsOfAbsence())
+		assert.Equal(t, 2, proof.Start())
+		assert.Equal(t, 3, proof.End())
+	})
+
+	t.Run("namespace is present", func(t *testing.T) {
+		_, _, err := ProveNamespaceAbsence(shares, nsTwo, appconsts.NewBaseHashFunc())
+		assert.Error(t, err)
+	})
+
+	t.Run("no shares", func(t *testing.T) {
+		_, _, err := ProveNamespaceAbsence(nil, nsThree, appconsts.NewBaseHashFunc())
+		assert.Error(t, err)
+	})
+
+	t.Run("shares out of namespace order", func(t *testing.T) {
+		_, _, err := ProveNamespaceAbsence([]Share{shares[2], shares[0]}, nsThree, appconsts.NewBaseHashFunc())
+		assert.Error(t, err)
+	})
+}
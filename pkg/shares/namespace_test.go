@@ -0,0 +1,125 @@
+package shares
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/celestiaorg/nmt/namespace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coretypes "github.com/tendermint/tendermint/types"
+)
+
+func TestBlobNamespaceRanges(t *testing.T) {
+	nsTwo := namespace.ID{2, 2, 2, 2, 2, 2, 2, 2}
+	txs := TxsToBytes(generateRandomTxs(2, 100))
+	blobs := []coretypes.Blob{
+		generateRandomBlobWithNamespace(nsOne, 100),
+		generateRandomBlobWithNamespace(nsOne, 1000),
+		generateRandomBlobWithNamespace(nsTwo, 100),
+	}
+	shares, err := BuildSquare(txs, blobs, 4)
+	require.NoError(t, err)
+
+	got, err := BlobNamespaceRanges(shares)
+	require.NoError(t, err)
+
+	// the tx share occupies index 0, the first blob occupies index 1, the
+	// second blob starts at the next multiple of its min square size (2) and
+	// occupies 2 shares, and the third blob is placed immediately after.
+	want := map[string][2]int{
+		hex.EncodeToString(nsOne): {1, 4},
+		hex.EncodeToString(nsTwo): {4, 5},
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestBlobNamespaceRangesNotContiguous(t *testing.T) {
+	nsTwo := namespace.ID{2, 2, 2, 2, 2, 2, 2, 2}
+	shares := []Share{
+		shareWithData(nsOne, true, 1, []byte{1}),
+		shareWithData(nsTwo, true, 1, []byte{1}),
+		shareWithData(nsOne, true, 1, []byte{1}),
+	}
+	_, err := BlobNamespaceRanges(shares)
+	assert.Error(t, err)
+}
+
+func TestForEachNamespaceWithBlobCount(t *testing.T) {
+	nsTwo := namespace.ID{2, 2, 2, 2, 2, 2, 2, 2}
+	txs := TxsToBytes(generateRandomTxs(2, 100))
+	blobs := []coretypes.Blob{
+		generateRandomBlobWithNamespace(nsOne, 100),
+		generateRandomBlobWithNamespace(nsOne, 1000),
+		generateRandomBlobWithNamespace(nsTwo, 100),
+	}
+	shares, err := BuildSquare(txs, blobs, 4)
+	require.NoError(t, err)
+
+	type namespaceCount struct {
+		ns        namespace.ID
+		blobCount int
+	}
+	got := []namespaceCount{}
+	err = ForEachNamespaceWithBlobCount(shares, func(ns namespace.ID, blobCount int) error {
+		got = append(got, namespaceCount{ns, blobCount})
+		return nil
+	})
+	require.NoError(t, err)
+	want := []namespaceCount{{nsOne, 2}, {nsTwo, 1}}
+	assert.Equal(t, want, got)
+
+	errStop := errors.New("stop")
+	calls := 0
+	err = ForEachNamespaceWithBlobCount(shares, func(ns namespace.ID, blobCount int) error {
+		calls++
+		return errStop
+	})
+	assert.ErrorIs(t, err, errStop)
+	assert.Equal(t, 1, calls)
+
+	unsorted := []Share{
+		shareWithData(nsTwo, true, 1, []byte{1}),
+		shareWithData(nsOne, true, 1, []byte{1}),
+	}
+	err = ForEachNamespaceWithBlobCount(unsorted, func(ns namespace.ID, blobCount int) error {
+		return nil
+	})
+	assert.Error(t, err)
+}
+
+func TestNamespaceRange(t *testing.T) {
+	nsTwo := namespace.ID{2, 2, 2, 2, 2, 2, 2, 2}
+	nsThree := namespace.ID{3, 3, 3, 3, 3, 3, 3, 3}
+	shares := []Share{
+		shareWithData(nsOne, true, 1, []byte{1}),
+		shareWithData(nsTwo, true, 1, []byte{2}),
+		shareWithData(nsThree, true, 1, []byte{3}),
+	}
+
+	type testCase struct {
+		name string
+		r    NamespaceRange
+		want []Share
+	}
+	testCases := []testCase{
+		{name: "single namespace", r: NamespaceRange{Min: nsTwo, Max: nsTwo}, want: shares[1:2]},
+		{name: "inclusive bounds", r: NamespaceRange{Min: nsOne, Max: nsTwo}, want: shares[:2]},
+		{name: "all namespaces", r: NamespaceRange{Min: namespace.ID{0, 0, 0, 0, 0, 0, 0, 0}, Max: namespace.ID{4, 4, 4, 4, 4, 4, 4, 4}}, want: shares},
+		{name: "no namespaces", r: NamespaceRange{Min: namespace.ID{4, 4, 4, 4, 4, 4, 4, 4}, Max: namespace.ID{5, 5, 5, 5, 5, 5, 5, 5}}, want: []Share{}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := SharesInRange(shares, tc.r)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	_, err := SharesInRange(shares, NamespaceRange{Min: nsTwo, Max: nsOne})
+	assert.Error(t, err)
+
+	_, err = NamespaceRange{Min: nsOne, Max: nsTwo}.Contains(&Share{data: []byte{1}})
+	assert.Error(t, err)
+}
@@ -2,6 +2,7 @@ package shares
 
 import (
 	"bytes"
+	"fmt"
 
 	"github.com/celestiaorg/celestia-app/pkg/appconsts"
 	"github.com/celestiaorg/nmt/namespace"
@@ -67,3 +68,28 @@ func TailPaddingShare() (Share, error) {
 func TailPaddingShares(n int) ([]Share, error) {
 	return NamespacePaddingShares(appconsts.TailPaddingNamespaceID, n)
 }
+
+// ValidatePaddingRegion returns an error if shares are not laid out as
+// expectedDataShares data shares followed by tail padding shares. Data shares
+// may include reserved padding and namespace padding because the
+// non-interactive default rules place those between significant shares but a
+// tail padding share must not appear before index expectedDataShares and every
+// share at or after index expectedDataShares must be tail padding.
+func ValidatePaddingRegion(shares []Share, expectedDataShares int) error {
+	if expectedDataShares < 0 || expectedDataShares > len(shares) {
+		return fmt.Errorf("expected data shares %d must be between 0 and the number of shares %d", expectedDataShares, len(shares))
+	}
+	for i, share := range shares {
+		if err := share.Validate(); err != nil {
+			return err
+		}
+		isTailPadding := share.isTailPadding()
+		if i < expectedDataShares && isTailPadding {
+			return fmt.Errorf("share %d is tail padding but expected a data share before index %d", i, expectedDataShares)
+		}
+		if i >= expectedDataShares && !isTailPadding {
+			return fmt.Errorf("share %d is not tail padding but expected tail padding after index %d", i, expectedDataShares)
+		}
+	}
+	return nil
+}
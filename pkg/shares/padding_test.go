@@ -70,3 +70,40 @@ func TestTailPaddingShares(t *testing.T) {
 		assert.Equal(t, tailPadding, share.ToBytes())
 	}
 }
+
+func TestValidatePaddingRegion(t *testing.T) {
+	data := shareWithData(nsOne, true, 1, []byte{1})
+	nsPadding, err := NamespacePaddingShare(nsOne)
+	require.NoError(t, err)
+	tail, err := TailPaddingShare()
+	require.NoError(t, err)
+
+	type testCase struct {
+		name               string
+		shares             []Share
+		expectedDataShares int
+		wantErr            bool
+	}
+	testCases := []testCase{
+		{name: "data followed by tail padding", shares: []Share{data, data, tail, tail}, expectedDataShares: 2},
+		{name: "namespace padding in data region", shares: []Share{data, nsPadding, data, tail}, expectedDataShares: 3},
+		{name: "only data", shares: []Share{data, data}, expectedDataShares: 2},
+		{name: "only tail padding", shares: []Share{tail, tail}, expectedDataShares: 0},
+		{name: "tail padding before data", shares: []Share{data, tail, data, tail}, expectedDataShares: 3, wantErr: true},
+		{name: "data after tail padding", shares: []Share{data, tail, data}, expectedDataShares: 1, wantErr: true},
+		{name: "fewer data shares than expected", shares: []Share{data, tail, tail}, expectedDataShares: 2, wantErr: true},
+		{name: "expected data shares exceeds shares", shares: []Share{data}, expectedDataShares: 2, wantErr: true},
+		{name: "invalid share", shares: []Share{{data: []byte{1}}}, expectedDataShares: 1, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidatePaddingRegion(tc.shares, tc.expectedDataShares)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -2,12 +2,20 @@ package shares
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
 	"fmt"
 
 	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/nmt/namespace"
 	coretypes "github.com/tendermint/tendermint/types"
 )
 
+// ErrBlobExceedsBudget is returned by ParseSharesBounded if the sequence length
+// of a blob exceeds the maximum number of bytes allowed per blob.
+var ErrBlobExceedsBudget = errors.New("blob exceeds the maximum number of bytes allowed per blob")
+
 // ParseTxs collects all of the transactions from the shares provided
 func ParseTxs(shares []Share) (coretypes.Txs, error) {
 	// parse the shares
@@ -25,6 +33,24 @@ func ParseTxs(shares []Share) (coretypes.Txs, error) {
 	return txs, nil
 }
 
+// ParseAndValidateTxs collects all of the transactions from the shares
+// provided and calls decode on each of them. It returns an error naming the
+// index of the first transaction that fails to decode.
+func ParseAndValidateTxs(shares []Share, decode func([]byte) error) ([][]byte, error) {
+	rawTxs, err := parseCompactShares(shares, appconsts.SupportedShareVersions)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, rawTx := range rawTxs {
+		if err := decode(rawTx); err != nil {
+			return nil, fmt.Errorf("failed to decode tx %d: %w", i, err)
+		}
+	}
+
+	return rawTxs, nil
+}
+
 // ParseBlobs collects all blobs from the shares provided
 func ParseBlobs(shares []Share) ([]coretypes.Blob, error) {
 	blobList, err := parseSparseShares(shares, appconsts.SupportedShareVersions)
@@ -35,6 +61,78 @@ func ParseBlobs(shares []Share) ([]coretypes.Blob, error) {
 	return blobList, nil
 }
 
+// ParseSharesExpectingNamespace collects all blobs from the shares provided. It
+// returns an error if any share has a namespace ID other than ns which allows
+// clients that requested the shares of a single namespace to reject shares from
+// other namespaces before parsing them.
+func ParseSharesExpectingNamespace(shares []Share, ns namespace.ID, supportedShareVersions []uint8) ([]coretypes.Blob, error) {
+	for i, share := range shares {
+		if err := share.Validate(); err != nil {
+			return nil, err
+		}
+		if !share.NamespaceID().Equal(ns) {
+			return nil, fmt.Errorf("share %d has namespace ID %x but expected namespace ID %x", i, share.NamespaceID(), ns)
+		}
+	}
+	return parseSparseShares(shares, supportedShareVersions)
+}
+
+// ParseSharesBounded collects all blobs from the shares provided. It returns an
+// error wrapping ErrBlobExceedsBudget if the sequence length of any blob
+// exceeds maxBlobBytes. The sequence lengths are checked before any blob is
+// reconstructed so a blob that exceeds the budget is never allocated.
+func ParseSharesBounded(shares []Share, supportedShareVersions []uint8, maxBlobBytes int) ([]coretypes.Blob, error) {
+	for i, share := range shares {
+		isPadding, err := share.IsPadding()
+		if err != nil {
+			return nil, err
+		}
+		if isPadding {
+			continue
+		}
+		sequenceLen, err := share.SequenceLen()
+		if err != nil {
+			return nil, err
+		}
+		if int64(sequenceLen) > int64(maxBlobBytes) {
+			return nil, fmt.Errorf("%w: share %d starts a blob of %d bytes but the maximum is %d bytes", ErrBlobExceedsBudget, i, sequenceLen, maxBlobBytes)
+		}
+	}
+	return parseSparseShares(shares, supportedShareVersions)
+}
+
+// BlobsDigest returns a SHA-256 digest over every blob in the data square
+// shares. Compact shares and padding are skipped so two squares that contain
+// the same blobs in the same order have the same digest regardless of the
+// padding between them. Each blob contributes its namespace ID, share version,
+// a big endian uint32 payload length, and its payload to the digest. The
+// payload length prevents the payload of one blob from being confused with the
+// namespace of the next.
+func BlobsDigest(shares []Share, supportedShareVersions []uint8) ([32]byte, error) {
+	compactShareCount, err := compactRegionEnd(shares)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	blobs, err := parseSparseShares(shares[compactShareCount:], supportedShareVersions)
+	if err != nil {
+		return [32]byte{}, err
+	}
+
+	hasher := sha256.New()
+	payloadLen := make([]byte, appconsts.SequenceLenBytes)
+	for _, blob := range blobs {
+		binary.BigEndian.PutUint32(payloadLen, uint32(len(blob.Data)))
+		hasher.Write(blob.NamespaceID)
+		hasher.Write([]byte{blob.ShareVersion})
+		hasher.Write(payloadLen)
+		hasher.Write(blob.Data)
+	}
+
+	var digest [32]byte
+	copy(digest[:], hasher.Sum(nil))
+	return digest, nil
+}
+
 func ParseShares(shares []Share) ([]ShareSequence, error) {
 	sequences := []ShareSequence{}
 	currentSequence := ShareSequence{}
@@ -1,6 +1,11 @@
 package shares
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+)
 
 // parseCompactShares returns data (transactions or intermediate state roots
 // based on the contents of rawShares and supportedShareVersions. If rawShares
@@ -81,3 +86,103 @@ func extractRawData(shares []Share) (rawData []byte, err error) {
 	}
 	return rawData, nil
 }
+
+// ValidateCompactFraming returns an error if the units in the compact share
+// sequence in shares are not framed consistently: every unit must be prefixed
+// by a length delimiter, the units must end exactly at the sequence length, and
+// the reserved bytes of every share must contain the location of the first
+// unit that starts in the share (or zero if no unit starts in the share). It
+// doesn't decode the units themselves.
+func ValidateCompactFraming(shares []Share) error {
+	if len(shares) == 0 {
+		return nil
+	}
+	for i, share := range shares {
+		if err := share.Validate(); err != nil {
+			return fmt.Errorf("share %d: %w", i, err)
+		}
+		if !share.IsCompactShare() {
+			return fmt.Errorf("share %d is not a compact share", i)
+		}
+		if !share.NamespaceID().Equal(shares[0].NamespaceID()) {
+			return fmt.Errorf("share %d has a different namespace than the start of the sequence", i)
+		}
+		isStart, err := share.IsSequenceStart()
+		if err != nil {
+			return fmt.Errorf("share %d: %w", i, err)
+		}
+		if isStart != (i == 0) {
+			return fmt.Errorf("share %d: only the first share may be the start of a sequence", i)
+		}
+	}
+	sequenceLen, err := shares[0].SequenceLen()
+	if err != nil {
+		return fmt.Errorf("share 0: %w", err)
+	}
+
+	// rawDataOffsets[i] is the offset of the raw data of share i in rawData
+	rawDataOffsets := make([]int, len(shares)+1)
+	rawData := []byte{}
+	for i, share := range shares {
+		raw, err := share.RawData()
+		if err != nil {
+			return fmt.Errorf("share %d: %w", i, err)
+		}
+		rawDataOffsets[i] = len(rawData)
+		rawData = append(rawData, raw...)
+	}
+	rawDataOffsets[len(shares)] = len(rawData)
+	if int(sequenceLen) > len(rawData) {
+		return fmt.Errorf("share 0: sequence length %d exceeds the %d bytes of raw data in the sequence", sequenceLen, len(rawData))
+	}
+
+	// unitStarts contains the offset in rawData of the start of every unit
+	unitStarts := []int{}
+	for cursor := 0; cursor < int(sequenceLen); {
+		shareIndex := shareIndexOfOffset(rawDataOffsets, cursor)
+		actualData, unitLen, err := ParseDelimiter(rawData[cursor:sequenceLen])
+		if err != nil {
+			return fmt.Errorf("share %d: invalid unit length delimiter at byte %d: %w", shareIndex, cursor-rawDataOffsets[shareIndex], err)
+		}
+		delimLen := len(rawData[cursor:sequenceLen]) - len(actualData)
+		if unitLen > uint64(len(actualData)) {
+			return fmt.Errorf("share %d: unit of length %d overruns the sequence length %d", shareIndex, unitLen, sequenceLen)
+		}
+		unitStarts = append(unitStarts, cursor)
+		cursor += delimLen + int(unitLen)
+	}
+
+	unit := 0
+	for i, share := range shares {
+		header, err := share.Header()
+		if err != nil {
+			return fmt.Errorf("share %d: %w", i, err)
+		}
+		reservedBytes, err := ParseReservedBytes(header[len(header)-appconsts.CompactShareReservedBytes:])
+		if err != nil {
+			return fmt.Errorf("share %d: %w", i, err)
+		}
+		for unit < len(unitStarts) && unitStarts[unit] < rawDataOffsets[i] {
+			unit++
+		}
+		want := uint32(0)
+		if unit < len(unitStarts) && unitStarts[unit] < rawDataOffsets[i+1] {
+			want = uint32(appconsts.NamespaceSize + len(header) + unitStarts[unit] - rawDataOffsets[i])
+		}
+		if reservedBytes != want {
+			return fmt.Errorf("share %d: reserved bytes point to byte %d but the first unit in the share starts at byte %d", i, reservedBytes, want)
+		}
+	}
+	return nil
+}
+
+// shareIndexOfOffset returns the index of the share whose raw data contains
+// offset given the offsets of the raw data of each share.
+func shareIndexOfOffset(rawDataOffsets []int, offset int) int {
+	for i := len(rawDataOffsets) - 2; i > 0; i-- {
+		if rawDataOffsets[i] <= offset {
+			return i
+		}
+	}
+	return 0
+}
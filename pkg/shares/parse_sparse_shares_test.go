@@ -152,3 +152,80 @@ func Test_parseSparseSharesWithNamespacedPadding(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, blobs, pblobs)
 }
+
+func TestParseSharesExpectingNamespace(t *testing.T) {
+	nsTwo := namespace.ID{2, 2, 2, 2, 2, 2, 2, 2}
+	blobs := []coretypes.Blob{
+		generateRandomBlobWithNamespace(nsOne, 100),
+		generateRandomBlobWithNamespace(nsOne, 1000),
+	}
+	shares, err := SplitBlobs(0, nil, blobs, false)
+	require.NoError(t, err)
+
+	got, err := ParseSharesExpectingNamespace(shares, nsOne, appconsts.SupportedShareVersions)
+	require.NoError(t, err)
+	assert.Equal(t, blobs, got)
+
+	_, err = ParseSharesExpectingNamespace(shares, nsTwo, appconsts.SupportedShareVersions)
+	assert.Error(t, err)
+
+	otherShares, err := SplitBlobs(0, nil, []coretypes.Blob{generateRandomBlobWithNamespace(nsTwo, 100)}, false)
+	require.NoError(t, err)
+	_, err = ParseSharesExpectingNamespace(append(shares, otherShares...), nsOne, appconsts.SupportedShareVersions)
+	assert.Error(t, err)
+}
+
+func TestParseSharesBounded(t *testing.T) {
+	small := generateRandomBlobWithNamespace(nsOne, 100)
+	large := generateRandomBlobWithNamespace(namespace.ID{2, 2, 2, 2, 2, 2, 2, 2}, 2000)
+	shares, err := SplitBlobs(0, nil, []coretypes.Blob{small, large}, false)
+	require.NoError(t, err)
+
+	got, err := ParseSharesBounded(shares, appconsts.SupportedShareVersions, 2000)
+	require.NoError(t, err)
+	assert.Equal(t, []coretypes.Blob{small, large}, got)
+
+	_, err = ParseSharesBounded(shares, appconsts.SupportedShareVersions, 1999)
+	assert.ErrorIs(t, err, ErrBlobExceedsBudget)
+
+	_, err = ParseSharesBounded(shares[:1], appconsts.SupportedShareVersions, 99)
+	assert.ErrorIs(t, err, ErrBlobExceedsBudget)
+
+	_, err = ParseSharesBounded(shares, []uint8{1}, 2000)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrBlobExceedsBudget)
+}
+
+func TestBlobsDigest(t *testing.T) {
+	blobs := []coretypes.Blob{
+		generateRandomBlobWithNamespace(nsOne, 100),
+		generateRandomBlobWithNamespace(namespace.ID{2, 2, 2, 2, 2, 2, 2, 2}, 2000),
+	}
+	unpadded, err := SplitBlobs(0, nil, blobs, false)
+	require.NoError(t, err)
+	padded, err := SplitBlobs(0, []uint32{0, 4}, blobs, true)
+	require.NoError(t, err)
+	require.Greater(t, len(padded), len(unpadded))
+	txShares, _, _, err := SplitTxs(generateRandomTxs(3, 100))
+	require.NoError(t, err)
+
+	want, err := BlobsDigest(unpadded, appconsts.SupportedShareVersions)
+	require.NoError(t, err)
+
+	got, err := BlobsDigest(padded, appconsts.SupportedShareVersions)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	got, err = BlobsDigest(append(txShares, unpadded...), appconsts.SupportedShareVersions)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	other, err := SplitBlobs(0, nil, blobs[:1], false)
+	require.NoError(t, err)
+	got, err = BlobsDigest(other, appconsts.SupportedShareVersions)
+	require.NoError(t, err)
+	assert.NotEqual(t, want, got)
+
+	_, err = BlobsDigest(unpadded, []uint8{1})
+	assert.Error(t, err)
+}
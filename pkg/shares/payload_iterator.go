@@ -0,0 +1,53 @@
+package shares
+
+// PayloadIterator iterates over the raw data of each share in a share
+// sequence. Unlike SequenceChunker, it doesn't copy the raw data: every chunk
+// it returns is a subslice of the data of a share so chunks must not be
+// modified and are only valid while the shares are.
+type PayloadIterator struct {
+	shares []Share
+	// remaining is the number of bytes in the sequence that have not been
+	// returned yet.
+	remaining int
+	// cursor is the index of the next share to return raw data from.
+	cursor int
+}
+
+// NewPayloadIterator returns a PayloadIterator over the share sequence in
+// shares. It returns an error if shares is not a single complete share
+// sequence.
+func NewPayloadIterator(shares []Share) (*PayloadIterator, error) {
+	if err := validateSingleSequence(shares); err != nil {
+		return nil, err
+	}
+	sequenceLen, err := shares[0].SequenceLen()
+	if err != nil {
+		return nil, err
+	}
+	return &PayloadIterator{
+		shares:    shares,
+		remaining: int(sequenceLen),
+	}, nil
+}
+
+// Next returns the raw data of the next share in the sequence and true, or
+// nil and false after the raw data of the last share has been returned. The
+// raw data of the last share is trimmed to the sequence length so it excludes
+// any padding.
+func (it *PayloadIterator) Next() ([]byte, bool) {
+	if it.remaining == 0 || it.cursor >= len(it.shares) {
+		return nil, false
+	}
+	// the shares were validated by NewPayloadIterator so they are large
+	// enough to contain raw data
+	raw, err := it.shares[it.cursor].RawData()
+	if err != nil {
+		return nil, false
+	}
+	if len(raw) > it.remaining {
+		raw = raw[:it.remaining]
+	}
+	it.remaining -= len(raw)
+	it.cursor++
+	return raw, true
+}
@@ -0,0 +1,76 @@
+package shares
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coretypes "github.com/tendermint/tendermint/types"
+)
+
+func TestPayloadIterator(t *testing.T) {
+	for _, size := range []int{1, appconsts.FirstSparseShareContentSize, 2000} {
+		blob := generateRandomBlobWithNamespace(nsOne, size)
+		shares, err := SplitBlobs(0, nil, []coretypes.Blob{blob}, false)
+		require.NoError(t, err)
+
+		it, err := NewPayloadIterator(shares)
+		require.NoError(t, err)
+
+		var got []byte
+		chunks := 0
+		for chunk, ok := it.Next(); ok; chunk, ok = it.Next() {
+			got = append(got, chunk...)
+			chunks++
+		}
+		assert.Equal(t, blob.Data, got)
+		assert.Equal(t, len(shares), chunks)
+	}
+}
+
+func TestPayloadIteratorAliasesShares(t *testing.T) {
+	blob := generateRandomBlobWithNamespace(nsOne, 100)
+	shares, err := SplitBlobs(0, nil, []coretypes.Blob{blob}, false)
+	require.NoError(t, err)
+
+	it, err := NewPayloadIterator(shares)
+	require.NoError(t, err)
+	chunk, ok := it.Next()
+	require.True(t, ok)
+	assert.Len(t, chunk, 100)
+
+	// modifying the share is visible through the chunk
+	rawData, err := shares[0].RawData()
+	require.NoError(t, err)
+	rawData[0] ^= 0xFF
+	assert.Equal(t, rawData[0], chunk[0])
+
+	_, ok = it.Next()
+	assert.False(t, ok)
+}
+
+func TestNewPayloadIteratorErrors(t *testing.T) {
+	shares, err := SplitBlobs(0, nil, []coretypes.Blob{generateRandomBlobWithNamespace(nsOne, 1000)}, false)
+	require.NoError(t, err)
+	require.Len(t, shares, 2)
+
+	type testCase struct {
+		name   string
+		shares []Share
+	}
+	testCases := []testCase{
+		{name: "no shares", shares: nil},
+		{name: "starts with a continuation share", shares: shares[1:]},
+		{name: "missing continuation share", shares: shares[:1]},
+		{name: "invalid share", shares: []Share{{data: bytes.Repeat([]byte{1}, 10)}}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewPayloadIterator(tc.shares)
+			assert.Error(t, err)
+		})
+	}
+}
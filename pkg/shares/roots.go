@@ -0,0 +1,149 @@
+package shares
+
+import (
+	"bytes"
+	"fmt"
+	"hash"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/nmt"
+	"github.com/tendermint/tendermint/crypto/merkle"
+)
+
+// VerifySquareRoots recomputes the row and column roots of the extended data
+// square provided and returns an error identifying the first row or column
+// whose root doesn't match rowRoots or colRoots. hasher is the base hash
+// function used to construct each namespaced Merkle tree (e.g.
+// appconsts.NewBaseHashFunc()). The roots are computed in the same way as
+// wrapper.ErasuredNamespacedMerkleTree so shares outside the original data
+// square are committed to in the parity namespace.
+func VerifySquareRoots(square [][]Share, rowRoots, colRoots [][]byte, hasher hash.Hash) error {
+	width := len(square)
+	if width == 0 || width%2 != 0 {
+		return fmt.Errorf("extended square width %d must be a positive even number", width)
+	}
+	if len(rowRoots) != width || len(colRoots) != width {
+		return fmt.Errorf("extended square of width %d must have %d row and column roots, got %d row roots and %d column roots", width, width, len(rowRoots), len(colRoots))
+	}
+	for i, row := range square {
+		if len(row) != width {
+			return fmt.Errorf("row %d has %d shares but the extended square width is %d", i, len(row), width)
+		}
+	}
+
+	squareSize := width / 2
+	if err := ValidateSquareSize(squareSize); err != nil {
+		return err
+	}
+	for i := 0; i < width; i++ {
+		root, err := computeAxisRoot(square[i], i, squareSize, hasher)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(root, rowRoots[i]) {
+			return fmt.Errorf("row %d root %x does not match expected root %x", i, root, rowRoots[i])
+		}
+	}
+	for j := 0; j < width; j++ {
+		col := make([]Share, width)
+		for i := 0; i < width; i++ {
+			col[i] = square[i][j]
+		}
+		root, err := computeAxisRoot(col, j, squareSize, hasher)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(root, colRoots[j]) {
+			return fmt.Errorf("column %d root %x does not match expected root %x", j, root, colRoots[j])
+		}
+	}
+	return nil
+}
+
+// VerifyBlock returns an error if the data square shares of width squareSize
+// don't match the row roots, column roots, and data root of a block. It checks
+// that:
+//   - shares contains squareSize*squareSize shares of supportedShareVersions
+//   - shares are in the canonical namespace order without user data in a
+//     reserved namespace
+//   - the extended data square of shares has the row and column roots provided
+//   - dataRoot is the Merkle root of the row roots followed by the column roots
+//
+// hasher is the base hash function used to construct each namespaced Merkle
+// tree (e.g. appconsts.NewBaseHashFunc()).
+func VerifyBlock(shares []Share, squareSize int, dataRoot []byte, rowRoots, colRoots [][]byte, supportedShareVersions []uint8, hasher hash.Hash) error {
+	if err := ValidateSquareSize(squareSize); err != nil {
+		return err
+	}
+	if len(shares) != squareSize*squareSize {
+		return fmt.Errorf("square of width %d must contain %d shares, got %d", squareSize, squareSize*squareSize, len(shares))
+	}
+	for i := range shares {
+		if err := shares[i].DoesSupportVersions(supportedShareVersions); err != nil {
+			return fmt.Errorf("share %d: %w", i, err)
+		}
+	}
+	if err := ValidateCanonicalNamespaceOrder(shares); err != nil {
+		return err
+	}
+	violations, err := FindReservedNamespaceViolations(shares)
+	if err != nil {
+		return err
+	}
+	if len(violations) != 0 {
+		return fmt.Errorf("shares %v contain user data in a reserved namespace", violations)
+	}
+
+	extended, err := ExtendSquare(shares, squareSize)
+	if err != nil {
+		return err
+	}
+	if err := VerifySquareRoots(extended, rowRoots, colRoots, hasher); err != nil {
+		return err
+	}
+
+	roots := make([][]byte, 0, len(rowRoots)+len(colRoots))
+	roots = append(roots, rowRoots...)
+	roots = append(roots, colRoots...)
+	if computed := merkle.HashFromByteSlices(roots); !bytes.Equal(computed, dataRoot) {
+		return fmt.Errorf("data root %x computed from the row and column roots does not match expected data root %x", computed, dataRoot)
+	}
+	return nil
+}
+
+// computeAxisRoot returns the namespaced Merkle root of the shares in the row
+// or column at axisIndex of an extended data square with an original width of
+// squareSize.
+func computeAxisRoot(shares []Share, axisIndex, squareSize int, hasher hash.Hash) ([]byte, error) {
+	tree, err := axisTree(shares, axisIndex, squareSize, hasher)
+	if err != nil {
+		return nil, err
+	}
+	return tree.Root(), nil
+}
+
+// axisTree returns the namespaced Merkle tree over the shares in the row or
+// column at axisIndex of an extended data square with an original width of
+// squareSize.
+func axisTree(shares []Share, axisIndex, squareSize int, hasher hash.Hash) (*nmt.NamespacedMerkleTree, error) {
+	tree := nmt.New(hasher, nmt.NamespaceIDSize(appconsts.NamespaceSize))
+	for shareIndex, share := range shares {
+		if err := tree.Push(leafData(share, axisIndex, shareIndex, squareSize)); err != nil {
+			return nil, err
+		}
+	}
+	return tree, nil
+}
+
+// leafData returns the leaf that share contributes to a namespaced Merkle tree
+// over the row or column at axisIndex. The leaf is the namespace of the share
+// followed by the share. Shares outside of the original data square use the
+// parity namespace.
+func leafData(share Share, axisIndex, shareIndex, squareSize int) []byte {
+	ns := appconsts.ParitySharesNamespaceID
+	if !IsParityPosition(axisIndex, shareIndex, squareSize) {
+		ns = share.NamespaceID()
+	}
+	leaf := make([]byte, 0, appconsts.NamespaceSize+len(share.data))
+	return append(append(leaf, ns...), share.data...)
+}
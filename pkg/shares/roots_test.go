@@ -0,0 +1,92 @@
+package shares
+
+import (
+	"testing"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/celestia-app/pkg/wrapper"
+	"github.com/celestiaorg/rsmt2d"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	coretypes "github.com/tendermint/tendermint/types"
+)
+
+func TestVerifySquareRoots(t *testing.T) {
+	squareSize := 4
+	blobs := []coretypes.Blob{generateRandomBlobWithNamespace(nsOne, 1000)}
+	original, err := BuildSquare(TxsToBytes(generateRandomTxs(2, 100)), blobs, squareSize)
+	require.NoError(t, err)
+
+	extended, err := ExtendSquare(original, squareSize)
+	require.NoError(t, err)
+	eds, err := rsmt2d.ComputeExtendedDataSquare(ToBytes(original), appconsts.DefaultCodec(), wrapper.NewConstructor(uint64(squareSize)))
+	require.NoError(t, err)
+	rowRoots, colRoots := eds.RowRoots(), eds.ColRoots()
+
+	err = VerifySquareRoots(extended, rowRoots, colRoots, appconsts.NewBaseHashFunc())
+	require.NoError(t, err)
+
+	t.Run("mismatched row root", func(t *testing.T) {
+		badRowRoots := append([][]byte{}, rowRoots...)
+		badRowRoots[5] = rowRoots[4]
+		err := VerifySquareRoots(extended, badRowRoots, colRoots, appconsts.NewBaseHashFunc())
+		assert.ErrorContains(t, err, "row 5")
+	})
+	t.Run("mismatched column root", func(t *testing.T) {
+		badColRoots := append([][]byte{}, colRoots...)
+		badColRoots[2] = colRoots[3]
+		err := VerifySquareRoots(extended, rowRoots, badColRoots, appconsts.NewBaseHashFunc())
+		assert.ErrorContains(t, err, "column 2")
+	})
+	t.Run("wrong number of roots", func(t *testing.T) {
+		err := VerifySquareRoots(extended, rowRoots[1:], colRoots, appconsts.NewBaseHashFunc())
+		assert.Error(t, err)
+	})
+	t.Run("square is not square", func(t *testing.T) {
+		err := VerifySquareRoots(extended[1:], rowRoots, colRoots, appconsts.NewBaseHashFunc())
+		assert.Error(t, err)
+	})
+}
+
+func TestVerifyBlock(t *testing.T) {
+	squareSize := 4
+	blobs := []coretypes.Blob{generateRandomBlobWithNamespace(nsOne, 1000)}
+	shares, err := BuildSquare(TxsToBytes(generateRandomTxs(2, 100)), blobs, squareSize)
+	require.NoError(t, err)
+
+	eds, err := rsmt2d.ComputeExtendedDataSquare(ToBytes(shares), appconsts.DefaultCodec(), wrapper.NewConstructor(uint64(squareSize)))
+	require.NoError(t, err)
+	rowRoots, colRoots := eds.RowRoots(), eds.ColRoots()
+	// the data root is computed in the same way as
+	// da.DataAvailabilityHeader.Hash
+	dataRoot := merkle.HashFromByteSlices(append(append([][]byte{}, rowRoots...), colRoots...))
+
+	err = VerifyBlock(shares, squareSize, dataRoot, rowRoots, colRoots, appconsts.SupportedShareVersions, appconsts.NewBaseHashFunc())
+	require.NoError(t, err)
+
+	t.Run("mismatched data root", func(t *testing.T) {
+		err := VerifyBlock(shares, squareSize, rowRoots[0], rowRoots, colRoots, appconsts.SupportedShareVersions, appconsts.NewBaseHashFunc())
+		assert.ErrorContains(t, err, "data root")
+	})
+	t.Run("mismatched row root", func(t *testing.T) {
+		badRowRoots := append([][]byte{}, rowRoots...)
+		badRowRoots[1] = rowRoots[0]
+		err := VerifyBlock(shares, squareSize, dataRoot, badRowRoots, colRoots, appconsts.SupportedShareVersions, appconsts.NewBaseHashFunc())
+		assert.ErrorContains(t, err, "row 1")
+	})
+	t.Run("shares out of order", func(t *testing.T) {
+		swapped := append([]Share{}, shares...)
+		swapped[0], swapped[len(swapped)-1] = swapped[len(swapped)-1], swapped[0]
+		err := VerifyBlock(swapped, squareSize, dataRoot, rowRoots, colRoots, appconsts.SupportedShareVersions, appconsts.NewBaseHashFunc())
+		assert.Error(t, err)
+	})
+	t.Run("unsupported share version", func(t *testing.T) {
+		err := VerifyBlock(shares, squareSize, dataRoot, rowRoots, colRoots, []uint8{1}, appconsts.NewBaseHashFunc())
+		assert.Error(t, err)
+	})
+	t.Run("wrong share count", func(t *testing.T) {
+		err := VerifyBlock(shares[1:], squareSize, dataRoot, rowRoots, colRoots, appconsts.SupportedShareVersions, appconsts.NewBaseHashFunc())
+		assert.Error(t, err)
+	})
+}
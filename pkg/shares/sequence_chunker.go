@@ -0,0 +1,110 @@
+package shares
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// SequenceChunker reads the raw data of a share sequence in chunks of a fixed
+// size. It allows callers to process the data of a large sequence in bounded
+// chunks rather than reconstructing the entire sequence at once. The data
+// returned excludes any padding after the sequence length.
+type SequenceChunker struct {
+	shares    []Share
+	chunkSize int
+	// remaining is the number of bytes in the sequence that have not been
+	// returned yet.
+	remaining int
+	// pending is the raw data of the current share that has not been returned
+	// yet.
+	pending []byte
+	// cursor is the index of the next share to read raw data from.
+	cursor int
+}
+
+// NewSequenceChunker returns a SequenceChunker over the share sequence in
+// shares. The first share must be the start of a sequence and chunkSize must
+// be positive.
+func NewSequenceChunker(shares []Share, chunkSize int) (*SequenceChunker, error) {
+	if chunkSize <= 0 {
+		return nil, fmt.Errorf("chunk size %d must be positive", chunkSize)
+	}
+	if len(shares) == 0 {
+		return nil, errors.New("no shares provided")
+	}
+	isStart, err := shares[0].IsSequenceStart()
+	if err != nil {
+		return nil, err
+	}
+	if !isStart {
+		return nil, errors.New("first share is not the start of a sequence")
+	}
+	sequenceLen, err := shares[0].SequenceLen()
+	if err != nil {
+		return nil, err
+	}
+	return &SequenceChunker{
+		shares:    shares,
+		chunkSize: chunkSize,
+		remaining: int(sequenceLen),
+	}, nil
+}
+
+// Next returns the next chunk of the sequence. Every chunk contains chunkSize
+// bytes except for the last chunk which may be shorter. Next returns io.EOF
+// after the last chunk has been returned.
+func (c *SequenceChunker) Next() ([]byte, error) {
+	if c.remaining == 0 {
+		return nil, io.EOF
+	}
+	size := c.chunkSize
+	if c.remaining < size {
+		size = c.remaining
+	}
+
+	chunk := make([]byte, 0, size)
+	for len(chunk) < size {
+		if len(c.pending) == 0 {
+			if err := c.readShare(); err != nil {
+				return nil, err
+			}
+		}
+		n := size - len(chunk)
+		if len(c.pending) < n {
+			n = len(c.pending)
+		}
+		chunk = append(chunk, c.pending[:n]...)
+		c.pending = c.pending[n:]
+	}
+	c.remaining -= size
+	return chunk, nil
+}
+
+// readShare sets pending to the raw data of the next share in the sequence.
+func (c *SequenceChunker) readShare() error {
+	if c.cursor >= len(c.shares) {
+		return fmt.Errorf("sequence ended after %d shares but %d bytes remain", len(c.shares), c.remaining)
+	}
+	share := c.shares[c.cursor]
+	if c.cursor > 0 {
+		isStart, err := share.IsSequenceStart()
+		if err != nil {
+			return err
+		}
+		if isStart {
+			return fmt.Errorf("share %d is the start of a new sequence", c.cursor)
+		}
+		if !bytes.Equal(share.NamespaceID(), c.shares[0].NamespaceID()) {
+			return fmt.Errorf("share %d has a different namespace than the start of the sequence", c.cursor)
+		}
+	}
+	raw, err := share.RawData()
+	if err != nil {
+		return err
+	}
+	c.pending = raw
+	c.cursor++
+	return nil
+}
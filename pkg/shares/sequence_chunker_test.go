@@ -0,0 +1,93 @@
+package shares
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/celestiaorg/nmt/namespace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coretypes "github.com/tendermint/tendermint/types"
+)
+
+func TestSequenceChunker(t *testing.T) {
+	type testCase struct {
+		name      string
+		blobSize  int
+		chunkSize int
+	}
+	testCases := []testCase{
+		{name: "one share, one chunk", blobSize: 100, chunkSize: 100},
+		{name: "one share, many chunks", blobSize: 100, chunkSize: 7},
+		{name: "many shares, chunk smaller than a share", blobSize: 2000, chunkSize: 300},
+		{name: "many shares, chunk larger than a share", blobSize: 2000, chunkSize: 1100},
+		{name: "chunk larger than the blob", blobSize: 2000, chunkSize: 5000},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			blob := generateRandomBlobWithNamespace(nsOne, tc.blobSize)
+			shares, err := SplitBlobs(0, nil, []coretypes.Blob{blob}, false)
+			require.NoError(t, err)
+
+			chunker, err := NewSequenceChunker(shares, tc.chunkSize)
+			require.NoError(t, err)
+
+			var got []byte
+			for {
+				chunk, err := chunker.Next()
+				if err == io.EOF {
+					break
+				}
+				require.NoError(t, err)
+				assert.LessOrEqual(t, len(chunk), tc.chunkSize)
+				got = append(got, chunk...)
+			}
+			assert.Equal(t, blob.Data, got)
+
+			_, err = chunker.Next()
+			assert.Equal(t, io.EOF, err)
+		})
+	}
+}
+
+func TestNewSequenceChunkerErrors(t *testing.T) {
+	start := shareWithData(nsOne, true, 1, []byte{1})
+	continuation := shareWithData(nsOne, false, 0, []byte{1})
+
+	_, err := NewSequenceChunker([]Share{start}, 0)
+	assert.Error(t, err)
+
+	_, err = NewSequenceChunker(nil, 1)
+	assert.Error(t, err)
+
+	_, err = NewSequenceChunker([]Share{continuation}, 1)
+	assert.Error(t, err)
+}
+
+func TestSequenceChunkerInvalidSequence(t *testing.T) {
+	// the sequence length requires a continuation share after start
+	sequenceLen := uint32(1000)
+	data := bytes.Repeat([]byte{1}, 100)
+	start := shareWithData(nsOne, true, sequenceLen, data)
+
+	type testCase struct {
+		name   string
+		shares []Share
+	}
+	testCases := []testCase{
+		{name: "missing continuation share", shares: []Share{start}},
+		{name: "new sequence start", shares: []Share{start, start}},
+		{name: "different namespace", shares: []Share{start, shareWithData(namespace.ID{2, 2, 2, 2, 2, 2, 2, 2}, false, 0, data)}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			chunker, err := NewSequenceChunker(tc.shares, int(sequenceLen))
+			require.NoError(t, err)
+			_, err = chunker.Next()
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package shares
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+)
+
+// ShareClass classifies a share by the role it plays in a data square.
+type ShareClass uint8
+
+const (
+	// TxShareClass is a compact share in the transaction namespace.
+	TxShareClass ShareClass = iota
+	// PayForBlobShareClass is a compact share in the PayForBlob namespace.
+	PayForBlobShareClass
+	// BlobShareClass is a sparse share that contains blob data.
+	BlobShareClass
+	// NamespacePaddingShareClass is a sparse share that pads a blob namespace.
+	NamespacePaddingShareClass
+	// ReservedPaddingShareClass is a share that pads the reserved namespaces.
+	ReservedPaddingShareClass
+	// TailPaddingShareClass is a share that pads the end of a data square.
+	TailPaddingShareClass
+	// ParityShareClass is a share that contains erasure coded data.
+	ParityShareClass
+)
+
+// String returns a human readable name for the share class.
+func (c ShareClass) String() string {
+	switch c {
+	case TxShareClass:
+		return "tx"
+	case PayForBlobShareClass:
+		return "pay for blob"
+	case BlobShareClass:
+		return "blob"
+	case NamespacePaddingShareClass:
+		return "namespace padding"
+	case ReservedPaddingShareClass:
+		return "reserved padding"
+	case TailPaddingShareClass:
+		return "tail padding"
+	case ParityShareClass:
+		return "parity"
+	default:
+		return fmt.Sprintf("unknown share class %d", uint8(c))
+	}
+}
+
+// Class returns the class of this share.
+func (s *Share) Class() (ShareClass, error) {
+	if s.isParity {
+		return ParityShareClass, nil
+	}
+	ns, err := s.namespaceIDOrErr()
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case ns.Equal(appconsts.TailPaddingNamespaceID):
+		return TailPaddingShareClass, nil
+	case ns.Equal(appconsts.ReservedPaddingNamespaceID):
+		return ReservedPaddingShareClass, nil
+	case ns.Equal(appconsts.TxNamespaceID):
+		return TxShareClass, nil
+	case ns.Equal(appconsts.PayForBlobNamespaceID):
+		return PayForBlobShareClass, nil
+	}
+	isNamespacePadding, err := s.isNamespacePadding()
+	if err != nil {
+		return 0, err
+	}
+	if isNamespacePadding {
+		return NamespacePaddingShareClass, nil
+	}
+	return BlobShareClass, nil
+}
+
+// ClassByte returns the class of this share encoded as a single byte. Use
+// ClassFromByte to decode it.
+func (s *Share) ClassByte() (byte, error) {
+	class, err := s.Class()
+	if err != nil {
+		return 0, err
+	}
+	return byte(class), nil
+}
+
+// ClassFromByte decodes a share class encoded by Share.ClassByte. It returns
+// an error if b is not a valid share class.
+func ClassFromByte(b byte) (ShareClass, error) {
+	if b > byte(ParityShareClass) {
+		return 0, fmt.Errorf("invalid share class byte %d", b)
+	}
+	return ShareClass(b), nil
+}
+
+// CompactKind distinguishes the compact shares in the transaction namespace
+// from the compact shares in the PayForBlob namespace. Both kinds share the
+// same layout but contain different units.
+type CompactKind uint8
+
+const (
+	// NotCompact is the kind of a share that is not a compact share.
+	NotCompact CompactKind = iota
+	// TxCompact is the kind of a compact share in the transaction namespace.
+	TxCompact
+	// PayForBlobCompact is the kind of a compact share in the PayForBlob
+	// namespace.
+	PayForBlobCompact
+)
+
+// String returns a human readable name for the compact kind.
+func (k CompactKind) String() string {
+	switch k {
+	case NotCompact:
+		return "not compact"
+	case TxCompact:
+		return "tx"
+	case PayForBlobCompact:
+		return "pay for blob"
+	default:
+		return fmt.Sprintf("unknown compact kind %d", uint8(k))
+	}
+}
+
+// CompactKind returns the kind of compact share this is or NotCompact if this
+// share is not a compact share.
+func (s *Share) CompactKind() (CompactKind, error) {
+	ns, err := s.namespaceIDOrErr()
+	if err != nil {
+		return NotCompact, err
+	}
+	switch {
+	case ns.Equal(appconsts.TxNamespaceID):
+		return TxCompact, nil
+	case ns.Equal(appconsts.PayForBlobNamespaceID):
+		return PayForBlobCompact, nil
+	default:
+		return NotCompact, nil
+	}
+}
+
+// FindReservedNamespaceViolations returns the indexes of the shares in shares
+// that contain user data in a reserved namespace. A share violates the
+// reserved namespaces if it is a blob or namespace padding share in a
+// namespace less than or equal to appconsts.MaxReservedNamespace or in the
+// parity namespace, or if it is in the reserved padding or tail padding
+// namespace but is not a padding share. Compact shares in the transaction and
+// PayForBlob namespaces are legitimate uses of those namespaces and parity
+// shares are skipped.
+func FindReservedNamespaceViolations(shares []Share) ([]int, error) {
+	violations := []int{}
+	for i := range shares {
+		if shares[i].IsParityShare() {
+			continue
+		}
+		if err := shares[i].Validate(); err != nil {
+			return nil, err
+		}
+		class, err := shares[i].Class()
+		if err != nil {
+			return nil, err
+		}
+		isViolation := false
+		switch class {
+		case BlobShareClass, NamespacePaddingShareClass:
+			ns := shares[i].NamespaceID()
+			isViolation = bytes.Compare(ns, appconsts.MaxReservedNamespace) <= 0 || ns.Equal(appconsts.ParitySharesNamespaceID)
+		case ReservedPaddingShareClass, TailPaddingShareClass:
+			isPadding, err := shares[i].isNamespacePadding()
+			if err != nil {
+				return nil, err
+			}
+			isViolation = !isPadding
+		}
+		if isViolation {
+			violations = append(violations, i)
+		}
+	}
+	return violations, nil
+}
@@ -0,0 +1,144 @@
+package shares
+
+import (
+	"testing"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/nmt/namespace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShareClass(t *testing.T) {
+	nsPadding, err := NamespacePaddingShare(nsOne)
+	require.NoError(t, err)
+	reservedPadding, err := ReservedPaddingShare()
+	require.NoError(t, err)
+	tailPadding, err := TailPaddingShare()
+	require.NoError(t, err)
+	parity, err := NewParityShare(make([]byte, appconsts.ShareSize))
+	require.NoError(t, err)
+
+	type testCase struct {
+		name    string
+		share   Share
+		want    ShareClass
+		wantErr bool
+	}
+	testCases := []testCase{
+		{name: "tx share", share: shareWithData(appconsts.TxNamespaceID, true, 1, []byte{1}), want: TxShareClass},
+		{name: "pay for blob share", share: shareWithData(appconsts.PayForBlobNamespaceID, false, 0, []byte{1}), want: PayForBlobShareClass},
+		{name: "blob start share", share: shareWithData(nsOne, true, 1, []byte{1}), want: BlobShareClass},
+		{name: "blob continuation share", share: shareWithData(nsOne, false, 0, []byte{1}), want: BlobShareClass},
+		{name: "namespace padding share", share: nsPadding, want: NamespacePaddingShareClass},
+		{name: "reserved padding share", share: reservedPadding, want: ReservedPaddingShareClass},
+		{name: "tail padding share", share: tailPadding, want: TailPaddingShareClass},
+		{name: "parity share", share: parity, want: ParityShareClass},
+		{name: "too short", share: Share{data: []byte{1}}, wantErr: true},
+		{name: "no sequence length", share: Share{data: []byte{1, 1, 1, 1, 1, 1, 1, 1, 1}}, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.share.Class()
+			if tc.wantErr {
+				assert.Error(t, err)
+				_, err = tc.share.ClassByte()
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+
+			b, err := tc.share.ClassByte()
+			require.NoError(t, err)
+			decoded, err := ClassFromByte(b)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, decoded)
+		})
+	}
+}
+
+func TestClassFromByteInvalid(t *testing.T) {
+	_, err := ClassFromByte(byte(ParityShareClass) + 1)
+	assert.Error(t, err)
+	_, err = ClassFromByte(0xFF)
+	assert.Error(t, err)
+}
+
+func TestShareClassString(t *testing.T) {
+	assert.Equal(t, "tail padding", TailPaddingShareClass.String())
+	assert.Equal(t, "unknown share class 200", ShareClass(200).String())
+}
+
+func TestCompactKind(t *testing.T) {
+	parity, err := NewParityShare(make([]byte, appconsts.ShareSize))
+	require.NoError(t, err)
+
+	type testCase struct {
+		name    string
+		share   Share
+		want    CompactKind
+		wantErr bool
+	}
+	testCases := []testCase{
+		{name: "tx share", share: shareWithData(appconsts.TxNamespaceID, true, 1, []byte{1}), want: TxCompact},
+		{name: "pay for blob share", share: shareWithData(appconsts.PayForBlobNamespaceID, false, 0, []byte{1}), want: PayForBlobCompact},
+		{name: "blob share", share: shareWithData(nsOne, true, 1, []byte{1}), want: NotCompact},
+		{name: "parity share", share: parity, want: NotCompact},
+		{name: "too short", share: Share{data: []byte{1}}, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.share.CompactKind()
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, tc.want != NotCompact, tc.share.IsCompactShare())
+		})
+	}
+}
+
+func TestFindReservedNamespaceViolations(t *testing.T) {
+	txShare := shareWithData(appconsts.TxNamespaceID, true, 1, []byte{0, 0, 0, 0, 1})
+	reservedPadding, err := ReservedPaddingShare()
+	require.NoError(t, err)
+	blob := shareWithData(nsOne, true, 1, []byte{1})
+	nsPadding, err := NamespacePaddingShare(nsOne)
+	require.NoError(t, err)
+	tailPadding, err := TailPaddingShare()
+	require.NoError(t, err)
+	parity, err := NewParityShare(blob.ToBytes())
+	require.NoError(t, err)
+
+	evidenceBlob := shareWithData(appconsts.EvidenceNamespaceID, true, 1, []byte{1})
+	reservedNamespacePadding, err := NamespacePaddingShare(namespace.ID{0, 0, 0, 0, 0, 0, 0, 5})
+	require.NoError(t, err)
+	tailPaddingBlob := shareWithData(appconsts.TailPaddingNamespaceID, true, 1, []byte{1})
+	reservedPaddingBlob := shareWithData(appconsts.ReservedPaddingNamespaceID, false, 0, []byte{1})
+	parityNamespaceBlob := shareWithData(appconsts.ParitySharesNamespaceID, true, 1, []byte{1})
+
+	shares := []Share{
+		txShare,                  // 0
+		evidenceBlob,             // 1
+		reservedPadding,          // 2
+		reservedPaddingBlob,      // 3
+		blob,                     // 4
+		nsPadding,                // 5
+		reservedNamespacePadding, // 6
+		tailPadding,              // 7
+		tailPaddingBlob,          // 8
+		parityNamespaceBlob,      // 9
+		parity,                   // 10
+	}
+	got, err := FindReservedNamespaceViolations(shares)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 3, 6, 8, 9}, got)
+
+	_, err = FindReservedNamespaceViolations([]Share{{data: []byte{1}}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,146 @@
+package shares
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+)
+
+// hexDumpWidth is the number of bytes displayed per line by AnnotatedBytes.
+const hexDumpWidth = 16
+
+// shareRegion is a named, contiguous range of bytes in a share.
+type shareRegion struct {
+	name  string
+	start int
+	end   int
+}
+
+// AnnotatedBytes returns a hexdump (similar to the output of `hexdump -C`) of
+// this share. Each line contains the offset of the first byte on the line, the
+// bytes in hex, and the bytes in ASCII. Each region of the share (namespace ID,
+// info byte, sequence length, reserved bytes, data) is preceded by a marker
+// with the name of the region. AnnotatedBytes does not panic if the share is
+// too short to contain all regions.
+func (s *Share) AnnotatedBytes() string {
+	var sb strings.Builder
+	for _, region := range s.regions() {
+		fmt.Fprintf(&sb, "%s:\n", region.name)
+		writeHexDump(&sb, s.data[region.start:region.end], region.start)
+	}
+	return sb.String()
+}
+
+// regions returns the regions present in this share. Regions that the share is
+// too short to contain are truncated or omitted.
+func (s *Share) regions() []shareRegion {
+	if s.isParity {
+		return []shareRegion{{name: "parity data", start: 0, end: len(s.data)}}
+	}
+
+	regions := []shareRegion{}
+	cursor := 0
+	add := func(name string, size int) {
+		if cursor >= len(s.data) {
+			return
+		}
+		end := cursor + size
+		if end > len(s.data) {
+			end = len(s.data)
+		}
+		regions = append(regions, shareRegion{name: name, start: cursor, end: end})
+		cursor = end
+	}
+
+	add("namespace ID", appconsts.NamespaceSize)
+	if len(s.data) <= appconsts.NamespaceSize {
+		return regions
+	}
+	add("info byte", appconsts.ShareInfoBytes)
+	isStart := InfoByte(s.data[appconsts.NamespaceSize]).IsSequenceStart()
+	if isStart {
+		add("sequence length", appconsts.SequenceLenBytes)
+	}
+	if s.IsCompactShare() {
+		add("reserved bytes", appconsts.CompactShareReservedBytes)
+	}
+	add("data", len(s.data))
+	return regions
+}
+
+// writeHexDump writes data to sb in the format of `hexdump -C`. The offset of
+// each line starts at offset.
+func writeHexDump(sb *strings.Builder, data []byte, offset int) {
+	for lineStart := 0; lineStart < len(data); lineStart += hexDumpWidth {
+		lineEnd := lineStart + hexDumpWidth
+		if lineEnd > len(data) {
+			lineEnd = len(data)
+		}
+		line := data[lineStart:lineEnd]
+
+		fmt.Fprintf(sb, "%08x  ", offset+lineStart)
+		for i := 0; i < hexDumpWidth; i++ {
+			if i < len(line) {
+				fmt.Fprintf(sb, "%02x ", line[i])
+			} else {
+				sb.WriteString("   ")
+			}
+			if i == hexDumpWidth/2-1 {
+				sb.WriteString(" ")
+			}
+		}
+
+		sb.WriteString(" |")
+		for _, b := range line {
+			if b >= 0x20 && b <= 0x7e {
+				sb.WriteByte(b)
+			} else {
+				sb.WriteByte('.')
+			}
+		}
+		sb.WriteString("|\n")
+	}
+}
+
+// TrailingZeroCount returns the number of trailing bytes of this share's raw
+// data that are zero. For the last share of a sequence this is at least the
+// number of bytes of padding written by the encoder, so a count less than the
+// padding expected from the sequence length indicates an encoder bug.
+func (s *Share) TrailingZeroCount() (int, error) {
+	rawData, err := s.RawData()
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for i := len(rawData) - 1; i >= 0 && rawData[i] == 0; i-- {
+		count++
+	}
+	return count, nil
+}
+
+// PayloadAsString returns the raw data of this share as a string and whether
+// it is valid UTF-8 so that callers can choose to display the payload as text
+// or as hex. If this share is the start of a sequence, the raw data is trimmed
+// to the sequence length so padding is excluded.
+func (s *Share) PayloadAsString() (payload string, isValidUTF8 bool, err error) {
+	rawData, err := s.RawData()
+	if err != nil {
+		return "", false, err
+	}
+	isStart, err := s.IsSequenceStart()
+	if err != nil {
+		return "", false, err
+	}
+	if isStart {
+		sequenceLen, err := s.SequenceLen()
+		if err != nil {
+			return "", false, err
+		}
+		if uint64(sequenceLen) < uint64(len(rawData)) {
+			rawData = rawData[:sequenceLen]
+		}
+	}
+	return string(rawData), utf8.Valid(rawData), nil
+}
@@ -0,0 +1,189 @@
+package shares
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coretypes "github.com/tendermint/tendermint/types"
+)
+
+func TestAnnotatedBytes(t *testing.T) {
+	type testCase struct {
+		name  string
+		share Share
+		want  string
+	}
+	testCases := []testCase{
+		{
+			name:  "empty share",
+			share: Share{},
+			want:  "",
+		},
+		{
+			name:  "share with a partial namespace",
+			share: Share{data: []byte{1, 1, 1}},
+			want: "namespace ID:\n" +
+				"00000000  01 01 01                                          |...|\n",
+		},
+		{
+			name: "first sparse share",
+			share: Share{data: []byte{
+				1, 1, 1, 1, 1, 1, 1, 1, // namespace
+				1,          // info byte
+				0, 0, 0, 2, // sequence len
+				'h', 'i', // data
+			}},
+			want: "namespace ID:\n" +
+				"00000000  01 01 01 01 01 01 01 01                           |........|\n" +
+				"info byte:\n" +
+				"00000008  01                                                |.|\n" +
+				"sequence length:\n" +
+				"00000009  00 00 00 02                                       |....|\n" +
+				"data:\n" +
+				"0000000d  68 69                                             |hi|\n",
+		},
+		{
+			name: "continuation compact share with truncated reserved bytes",
+			share: Share{data: []byte{
+				0, 0, 0, 0, 0, 0, 0, 1, // namespace
+				0,    // info byte
+				0, 0, // partial reserved bytes
+			}},
+			want: "namespace ID:\n" +
+				"00000000  00 00 00 00 00 00 00 01                           |........|\n" +
+				"info byte:\n" +
+				"00000008  00                                                |.|\n" +
+				"reserved bytes:\n" +
+				"00000009  00 00                                             |..|\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.share.AnnotatedBytes())
+		})
+	}
+}
+
+func TestAnnotatedBytesFullShare(t *testing.T) {
+	share, err := TailPaddingShare()
+	require.NoError(t, err)
+	got := share.AnnotatedBytes()
+	// the data region spans multiple lines and ends with the last byte of the share
+	assert.Contains(t, got, "data:\n0000000d  00 00 00")
+	assert.Contains(t, got, "000001fd  00 00 00")
+	assert.NotContains(t, got, "00000200")
+}
+
+func TestTrailingZeroCount(t *testing.T) {
+	type testCase struct {
+		name    string
+		share   Share
+		want    int
+		wantErr bool
+	}
+	testCases := []testCase{
+		{
+			name:  "first sparse share with one byte of data",
+			share: shareWithData(nsOne, true, 1, []byte{1}),
+			want:  appconsts.FirstSparseShareContentSize - 1,
+		},
+		{
+			name:  "continuation sparse share with data ending in zero",
+			share: shareWithData(nsOne, false, 0, []byte{1, 0, 0}),
+			want:  appconsts.ContinuationSparseShareContentSize - 1,
+		},
+		{
+			name:  "full share",
+			share: shareWithData(nsOne, false, 0, bytes.Repeat([]byte{1}, appconsts.ContinuationSparseShareContentSize)),
+			want:  0,
+		},
+		{
+			name:    "too short",
+			share:   Share{data: []byte{1}},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.share.TrailingZeroCount()
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestTrailingZeroCountMatchesSequenceLen(t *testing.T) {
+	data := bytes.Repeat([]byte{0xFF}, 1000)
+	shares, err := SplitBlobs(0, nil, []coretypes.Blob{{NamespaceID: nsOne, Data: data}}, false)
+	require.NoError(t, err)
+	require.Len(t, shares, 2)
+
+	last := shares[len(shares)-1]
+	got, err := last.TrailingZeroCount()
+	require.NoError(t, err)
+	wantPadding := appconsts.FirstSparseShareContentSize + appconsts.ContinuationSparseShareContentSize - len(data)
+	assert.Equal(t, wantPadding, got)
+}
+
+func TestPayloadAsString(t *testing.T) {
+	type testCase struct {
+		name          string
+		share         Share
+		want          string
+		wantValidUTF8 bool
+		wantErr       bool
+	}
+	testCases := []testCase{
+		{
+			name:          "text in a start share",
+			share:         shareWithData(nsOne, true, 5, []byte("hello")),
+			want:          "hello",
+			wantValidUTF8: true,
+		},
+		{
+			name:          "binary data in a start share",
+			share:         shareWithData(nsOne, true, 2, []byte{0xFF, 0xFE}),
+			want:          string([]byte{0xFF, 0xFE}),
+			wantValidUTF8: false,
+		},
+		{
+			name:          "text in a compact start share",
+			share:         shareWithData(appconsts.TxNamespaceID, true, 2, []byte{0, 0, 0, 0, 'h', 'i'}),
+			want:          "hi",
+			wantValidUTF8: true,
+		},
+		{
+			name:          "continuation share",
+			share:         shareWithData(nsOne, false, 0, []byte("hello")),
+			want:          "hello" + string(make([]byte, appconsts.ContinuationSparseShareContentSize-5)),
+			wantValidUTF8: true,
+		},
+		{
+			name:    "too short",
+			share:   Share{data: []byte{1}},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, isValidUTF8, err := tc.share.PayloadAsString()
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, tc.wantValidUTF8, isValidUTF8)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package shares
+
+import (
+	"errors"
+	"fmt"
+	"hash"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/nmt"
+)
+
+// ShareWithProof is a share of a data square bundled with a proof of its
+// inclusion in the root of its row of the extended data square so that a
+// client can verify the share without the rest of the square.
+type ShareWithProof struct {
+	Share Share
+	// Proof is the proof of inclusion of Share in RowRoot.
+	Proof nmt.Proof
+	// RowRoot is the root of the row of the extended data square that
+	// contains Share.
+	RowRoot []byte
+}
+
+// NewShareWithProof returns the share at flat index shareIndex of the data
+// square of width squareSize along with a proof of its inclusion in the root of
+// its row of the extended data square. hasher is the base hash function used
+// to construct the namespaced Merkle tree of the row (e.g.
+// appconsts.NewBaseHashFunc()).
+func NewShareWithProof(square []Share, squareSize, shareIndex int, hasher hash.Hash) (*ShareWithProof, error) {
+	if err := ValidateSquareSize(squareSize); err != nil {
+		return nil, err
+	}
+	if len(square) != squareSize*squareSize {
+		return nil, fmt.Errorf("square of width %d must contain %d shares, got %d", squareSize, squareSize*squareSize, len(square))
+	}
+	if !isValidFlatIndex(shareIndex, squareSize) {
+		return nil, fmt.Errorf("share index %d is out of range for a square of width %d", shareIndex, squareSize)
+	}
+
+	rowIndex, col := rowCol(shareIndex, squareSize)
+	original := square[rowIndex*squareSize : (rowIndex+1)*squareSize]
+	for _, share := range original {
+		if err := share.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	// the parity half of a row in the top half of the extended data square
+	// is the erasure coding of the original half of the row
+	parity, err := appconsts.DefaultCodec().Encode(ToBytes(original))
+	if err != nil {
+		return nil, err
+	}
+	row := make([]Share, 0, 2*squareSize)
+	row = append(row, original...)
+	for _, data := range parity {
+		share, err := NewParityShare(data)
+		if err != nil {
+			return nil, err
+		}
+		row = append(row, share)
+	}
+
+	tree, err := axisTree(row, rowIndex, squareSize, hasher)
+	if err != nil {
+		return nil, err
+	}
+	proof, err := tree.Prove(col)
+	if err != nil {
+		return nil, err
+	}
+	return &ShareWithProof{Share: square[shareIndex], Proof: proof, RowRoot: tree.Root()}, nil
+}
+
+// Verify returns an error if Proof doesn't prove the inclusion of Share in
+// RowRoot. hasher must be the base hash function that was used to construct
+// the proof.
+func (s *ShareWithProof) Verify(hasher hash.Hash) error {
+	if err := s.Share.Validate(); err != nil {
+		return err
+	}
+	if !s.Proof.VerifyInclusion(hasher, s.Share.NamespaceID(), [][]byte{s.Share.data}, s.RowRoot) {
+		return errors.New("share is not included in the row root")
+	}
+	return nil
+}
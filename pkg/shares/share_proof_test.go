@@ -0,0 +1,47 @@
+package shares
+
+import (
+	"testing"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/nmt/namespace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coretypes "github.com/tendermint/tendermint/types"
+)
+
+func TestNewShareWithProof(t *testing.T) {
+	squareSize := 4
+	txs := TxsToBytes(generateRandomTxs(2, 100))
+	blobs := []coretypes.Blob{
+		generateRandomBlobWithNamespace(nsOne, 100),
+		generateRandomBlobWithNamespace(namespace.ID{2, 2, 2, 2, 2, 2, 2, 2}, 1000),
+	}
+	square, err := BuildSquare(txs, blobs, squareSize)
+	require.NoError(t, err)
+	extended, err := ExtendSquare(square, squareSize)
+	require.NoError(t, err)
+
+	for shareIndex := range square {
+		shareWithProof, err := NewShareWithProof(square, squareSize, shareIndex, appconsts.NewBaseHashFunc())
+		require.NoError(t, err)
+		assert.Equal(t, square[shareIndex], shareWithProof.Share)
+		assert.NoError(t, shareWithProof.Verify(appconsts.NewBaseHashFunc()), "share %d", shareIndex)
+
+		// the row root matches the row root of the extended data square
+		row, _ := rowCol(shareIndex, squareSize)
+		rowRoot, err := computeAxisRoot(extended[row], row, squareSize, appconsts.NewBaseHashFunc())
+		require.NoError(t, err)
+		assert.Equal(t, rowRoot, shareWithProof.RowRoot)
+	}
+
+	shareWithProof, err := NewShareWithProof(square, squareSize, 1, appconsts.NewBaseHashFunc())
+	require.NoError(t, err)
+	shareWithProof.Share = square[2]
+	assert.Error(t, shareWithProof.Verify(appconsts.NewBaseHashFunc()))
+
+	_, err = NewShareWithProof(square, squareSize, len(square), appconsts.NewBaseHashFunc())
+	assert.Error(t, err)
+	_, err = NewShareWithProof(square[:15], squareSize, 0, appconsts.NewBaseHashFunc())
+	assert.Error(t, err)
+}
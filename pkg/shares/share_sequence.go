@@ -1,7 +1,10 @@
 package shares
 
 import (
+	"encoding/binary"
+	"errors"
 	"fmt"
+	"io"
 
 	"github.com/celestiaorg/celestia-app/pkg/appconsts"
 	"github.com/celestiaorg/nmt/namespace"
@@ -34,6 +37,93 @@ func (s ShareSequence) RawData() (data []byte, err error) {
 	return data[:sequenceLen], nil
 }
 
+// WriteSequenceTo writes the raw data of the share sequence in shares to w
+// without buffering the entire sequence in memory. Any padding after the
+// sequence length in the last share is not written. It returns the number of
+// bytes written and an error if the shares don't form exactly one share
+// sequence.
+func WriteSequenceTo(w io.Writer, shares []Share) (int64, error) {
+	if err := validateSingleSequence(shares); err != nil {
+		return 0, err
+	}
+	sequenceLen, err := shares[0].SequenceLen()
+	if err != nil {
+		return 0, err
+	}
+
+	remaining := int64(sequenceLen)
+	written := int64(0)
+	for _, share := range shares {
+		raw, err := share.RawData()
+		if err != nil {
+			return written, err
+		}
+		if int64(len(raw)) > remaining {
+			raw = raw[:remaining]
+		}
+		n, err := w.Write(raw)
+		written += int64(n)
+		if err != nil {
+			return written, err
+		}
+		remaining -= int64(n)
+	}
+	return written, nil
+}
+
+// validateSingleSequence returns an error if shares do not form exactly one
+// share sequence. The first share must be the start of a sequence, the
+// remaining shares must be continuation shares in the same namespace, and the
+// number of shares must match the sequence length.
+func validateSingleSequence(shares []Share) error {
+	if len(shares) == 0 {
+		return errors.New("no shares provided")
+	}
+	for i, share := range shares {
+		if err := share.Validate(); err != nil {
+			return err
+		}
+		isStart, err := share.IsSequenceStart()
+		if err != nil {
+			return err
+		}
+		if i == 0 && !isStart {
+			return errors.New("first share is not the start of a sequence")
+		}
+		if i > 0 && isStart {
+			return fmt.Errorf("share %d is the start of a new sequence", i)
+		}
+		if !share.NamespaceID().Equal(shares[0].NamespaceID()) {
+			return fmt.Errorf("share %d has a different namespace than the start of the sequence", i)
+		}
+	}
+	return ShareSequence{NamespaceID: shares[0].NamespaceID(), Shares: shares}.validSequenceLen()
+}
+
+// IsSequenceBoundary returns true if curr starts a new sequence after prev. prev
+// may be nil if curr is the first share. It returns an error if curr is a
+// continuation share that doesn't belong to the same namespace as prev.
+func IsSequenceBoundary(prev, curr *Share) (bool, error) {
+	infoByte, err := curr.InfoByte()
+	if err != nil {
+		return false, err
+	}
+	if infoByte.IsSequenceStart() {
+		return true, nil
+	}
+	if prev == nil {
+		return false, errors.New("continuation share is not preceded by a share")
+	}
+	prevNamespace, err := prev.namespaceIDOrErr()
+	if err != nil {
+		return false, err
+	}
+	if !curr.NamespaceID().Equal(prevNamespace) {
+		return false, fmt.Errorf("continuation share has namespace %x but the previous share has namespace %x", curr.NamespaceID(), prevNamespace)
+	}
+	return false, nil
+}
+
 func (s ShareSequence) SequenceLen() (uint32, error) {
 	if len(s.Shares) == 0 {
 		return 0, fmt.Errorf("invalid sequence length because share sequence %v has no shares", s)
@@ -62,6 +152,36 @@ func (s ShareSequence) validSequenceLen() error {
 	return nil
 }
 
+// SetSequenceLenForBlob writes totalLen as the sequence length of the first
+// share in shares. It returns an error without modifying shares if the first
+// share is not the start of a sequence or if a sequence of length totalLen
+// doesn't occupy exactly len(shares) shares.
+func SetSequenceLenForBlob(shares []Share, totalLen uint32) error {
+	if len(shares) == 0 {
+		return errors.New("cannot set the sequence length of zero shares")
+	}
+	firstShare := shares[0]
+	if err := firstShare.Validate(); err != nil {
+		return err
+	}
+	isSequenceStart, err := firstShare.IsSequenceStart()
+	if err != nil {
+		return err
+	}
+	if !isSequenceStart {
+		return errors.New("cannot set the sequence length of a share that is not the start of a sequence")
+	}
+
+	sharesNeeded := sharesOccupied(totalLen, firstShare.IsCompactShare())
+	if len(shares) != sharesNeeded {
+		return fmt.Errorf("sequence length %d needs %d shares but got %d shares", totalLen, sharesNeeded, len(shares))
+	}
+
+	start := appconsts.NamespaceSize + appconsts.ShareInfoBytes
+	binary.BigEndian.PutUint32(firstShare.data[start:start+appconsts.SequenceLenBytes], totalLen)
+	return nil
+}
+
 // numberOfSharesNeeded extracts the sequenceLen written to the share
 // firstShare and returns the number of shares needed to store a sequence of
 // that length.
@@ -71,10 +191,27 @@ func numberOfSharesNeeded(firstShare Share) (sharesUsed int, err error) {
 		return 0, err
 	}
 
-	if firstShare.IsCompactShare() {
-		return CompactSharesNeeded(int(sequenceLen)), nil
+	return sequenceSharesNeeded(sequenceLen, firstShare.IsCompactShare()), nil
+}
+
+// sequenceSharesNeeded returns the number of compact shares (if isCompact) or
+// sparse shares needed to store a sequence of length sequenceLen.
+func sequenceSharesNeeded(sequenceLen uint32, isCompact bool) int {
+	if isCompact {
+		return CompactSharesNeeded(int(sequenceLen))
+	}
+	return SparseSharesNeeded(sequenceLen)
+}
+
+// sharesOccupied returns the number of shares occupied by a sequence of length
+// sequenceLen. Unlike sequenceSharesNeeded, a sequence of length zero (e.g.
+// namespace padding) still occupies the share that contains its sequence
+// length.
+func sharesOccupied(sequenceLen uint32, isCompact bool) int {
+	if sequenceLen == 0 {
+		return 1
 	}
-	return SparseSharesNeeded(sequenceLen), nil
+	return sequenceSharesNeeded(sequenceLen, isCompact)
 }
 
 // CompactSharesNeeded returns the number of compact shares needed to store a
@@ -9,6 +9,8 @@ import (
 	testns "github.com/celestiaorg/celestia-app/testutil/namespace"
 	"github.com/celestiaorg/nmt/namespace"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coretypes "github.com/tendermint/tendermint/types"
 )
 
 func TestShareSequenceRawData(t *testing.T) {
@@ -122,6 +124,50 @@ func Test_sparseSharesNeeded(t *testing.T) {
 	}
 }
 
+func TestWriteSequenceTo(t *testing.T) {
+	for _, size := range []int{1, 100, appconsts.FirstSparseShareContentSize, 2000} {
+		blob := generateRandomBlobWithNamespace(nsOne, size)
+		shares, err := SplitBlobs(0, nil, []coretypes.Blob{blob}, false)
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		n, err := WriteSequenceTo(&buf, shares)
+		require.NoError(t, err)
+		assert.Equal(t, int64(size), n)
+		assert.Equal(t, blob.Data, buf.Bytes())
+	}
+}
+
+func TestWriteSequenceToErrors(t *testing.T) {
+	nsTwo := namespace.ID{2, 2, 2, 2, 2, 2, 2, 2}
+	blob := generateRandomBlobWithNamespace(nsOne, 1000)
+	shares, err := SplitBlobs(0, nil, []coretypes.Blob{blob}, false)
+	require.NoError(t, err)
+	require.Len(t, shares, 2)
+
+	type testCase struct {
+		name   string
+		shares []Share
+	}
+	testCases := []testCase{
+		{name: "no shares", shares: nil},
+		{name: "starts with a continuation share", shares: shares[1:]},
+		{name: "missing continuation share", shares: shares[:1]},
+		{name: "two sequence starts", shares: []Share{shares[0], shares[0]}},
+		{name: "extra continuation share", shares: []Share{shares[0], shares[1], shares[1]}},
+		{name: "different namespace", shares: []Share{shares[0], shareWithData(nsTwo, false, 0, []byte{1})}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			_, err := WriteSequenceTo(&buf, tc.shares)
+			assert.Error(t, err)
+			assert.Zero(t, buf.Len())
+		})
+	}
+}
+
 func shareWithData(namespace namespace.ID, isSequenceStart bool, sequenceLen uint32, data []byte) (rawShare Share) {
 	infoByte, _ := NewInfoByte(appconsts.ShareVersionZero, isSequenceStart)
 	rawShareBytes := make([]byte, 0, appconsts.ShareSize)
@@ -136,3 +182,88 @@ func shareWithData(namespace namespace.ID, isSequenceStart bool, sequenceLen uin
 
 	return padShare(Share{data: rawShareBytes})
 }
+
+func TestSetSequenceLenForBlob(t *testing.T) {
+	blob := generateRandomBlobWithNamespace(nsOne, 1000)
+	shares, err := SplitBlobs(0, nil, []coretypes.Blob{blob}, false)
+	require.NoError(t, err)
+	require.Len(t, shares, 2)
+
+	t.Run("sequence length consistent with share count", func(t *testing.T) {
+		err := SetSequenceLenForBlob(shares, 600)
+		require.NoError(t, err)
+		got, err := shares[0].SequenceLen()
+		require.NoError(t, err)
+		assert.Equal(t, uint32(600), got)
+	})
+
+	t.Run("sequence length needs fewer shares", func(t *testing.T) {
+		err := SetSequenceLenForBlob(shares, 100)
+		assert.Error(t, err)
+		got, err := shares[0].SequenceLen()
+		require.NoError(t, err)
+		assert.Equal(t, uint32(600), got, "shares must not be modified on error")
+	})
+
+	t.Run("sequence length needs more shares", func(t *testing.T) {
+		assert.Error(t, SetSequenceLenForBlob(shares, 2000))
+	})
+
+	t.Run("compact shares", func(t *testing.T) {
+		txShares, _, _, err := SplitTxs(generateRandomTxs(1, 100))
+		require.NoError(t, err)
+		require.Len(t, txShares, 1)
+		assert.NoError(t, SetSequenceLenForBlob(txShares, appconsts.FirstCompactShareContentSize))
+		assert.Error(t, SetSequenceLenForBlob(txShares, appconsts.FirstCompactShareContentSize+1))
+	})
+
+	t.Run("namespace padding", func(t *testing.T) {
+		padding, err := NamespacePaddingShare(nsOne)
+		require.NoError(t, err)
+		assert.NoError(t, SetSequenceLenForBlob([]Share{padding}, 0))
+	})
+
+	t.Run("starts with a continuation share", func(t *testing.T) {
+		assert.Error(t, SetSequenceLenForBlob(shares[1:], 1))
+	})
+
+	t.Run("no shares", func(t *testing.T) {
+		assert.Error(t, SetSequenceLenForBlob(nil, 1))
+	})
+}
+
+func TestIsSequenceBoundary(t *testing.T) {
+	nsTwo := namespace.ID{2, 2, 2, 2, 2, 2, 2, 2}
+	start := shareWithData(nsOne, true, 1000, []byte{1})
+	continuation := shareWithData(nsOne, false, 0, []byte{1})
+	otherStart := shareWithData(nsTwo, true, 1, []byte{1})
+	otherContinuation := shareWithData(nsTwo, false, 0, []byte{1})
+
+	type testCase struct {
+		name       string
+		prev, curr *Share
+		want       bool
+		wantErr    bool
+	}
+	testCases := []testCase{
+		{name: "first share", prev: nil, curr: &start, want: true},
+		{name: "start after continuation", prev: &continuation, curr: &start, want: true},
+		{name: "start in a different namespace", prev: &start, curr: &otherStart, want: true},
+		{name: "continuation", prev: &start, curr: &continuation, want: false},
+		{name: "continuation in a different namespace", prev: &start, curr: &otherContinuation, wantErr: true},
+		{name: "continuation without a previous share", prev: nil, curr: &continuation, wantErr: true},
+		{name: "too short", prev: &start, curr: &Share{data: []byte{1}}, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := IsSequenceBoundary(tc.prev, tc.curr)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
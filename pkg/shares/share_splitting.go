@@ -1,6 +1,7 @@
 package shares
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"sort"
@@ -83,6 +84,75 @@ func Split(data coretypes.Data, useShareIndexes bool) ([]Share, error) {
 	return shares, nil
 }
 
+// BuildSquare returns the ordered shares of a data square of width
+// squareSize. The square contains the compact shares for txs (ordinary
+// transactions followed by PFB transactions), then the sparse shares for blobs
+// sorted by namespace and laid out according to the non-interactive default
+// rules, then tail padding shares to fill the square. Reserved padding shares
+// and namespace padding shares are inserted where the non-interactive default
+// rules require them. It returns an error if the txs and blobs don't fit in a
+// square of width squareSize.
+func BuildSquare(txs [][]byte, blobs []coretypes.Blob, squareSize int) ([]Share, error) {
+	if err := ValidateSquareSize(squareSize); err != nil {
+		return nil, err
+	}
+	wantShareCount := squareSize * squareSize
+
+	txShares, pfbTxShares, _, err := SplitTxs(TxsFromBytes(txs))
+	if err != nil {
+		return nil, err
+	}
+	currentShareCount := len(txShares) + len(pfbTxShares)
+
+	sortedBlobs := make([]coretypes.Blob, len(blobs))
+	copy(sortedBlobs, blobs)
+	sort.SliceStable(sortedBlobs, func(i, j int) bool {
+		return bytes.Compare(sortedBlobs[i].NamespaceID, sortedBlobs[j].NamespaceID) < 0
+	})
+
+	var (
+		padding    []Share
+		blobShares []Share
+	)
+	if len(sortedBlobs) > 0 {
+		blobShareLens := make([]int, len(sortedBlobs))
+		for i, blob := range sortedBlobs {
+			blobShareLens[i] = SparseSharesNeeded(uint32(len(blob.Data)))
+		}
+		_, blobIndexes := BlobSharesUsedNonInteractiveDefaults(currentShareCount, squareSize, blobShareLens...)
+
+		padding, err = ReservedPaddingShares(int(blobIndexes[0]) - currentShareCount)
+		if err != nil {
+			return nil, err
+		}
+		currentShareCount += len(padding)
+
+		blobShares, err = SplitBlobs(currentShareCount, blobIndexes, sortedBlobs, true)
+		if err != nil {
+			return nil, err
+		}
+		currentShareCount += len(blobShares)
+	}
+
+	if currentShareCount > wantShareCount {
+		return nil, fmt.Errorf("txs and blobs need %d shares which exceeds the %d shares in a square of size %d", currentShareCount, wantShareCount, squareSize)
+	}
+	tailShares, err := TailPaddingShares(wantShareCount - currentShareCount)
+	if err != nil {
+		return nil, err
+	}
+
+	shares := make([]Share, 0, wantShareCount)
+	shares = append(append(append(append(append(
+		shares,
+		txShares...),
+		pfbTxShares...),
+		padding...),
+		blobShares...),
+		tailShares...)
+	return shares, nil
+}
+
 // ExtractShareIndexes iterates over the transactions and extracts the share
 // indexes from wrapped transactions. It returns nil if the transactions are
 // from an old block that did not have share indexes in the wrapped txs.
@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/nmt/namespace"
+	"github.com/celestiaorg/rsmt2d"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	coretypes "github.com/tendermint/tendermint/types"
@@ -253,6 +255,53 @@ func fillShare(share Share, filler byte) (paddedShare Share) {
 	return Share{data: append(share.data, bytes.Repeat([]byte{filler}, appconsts.ShareSize-len(share.data))...)}
 }
 
+func TestBuildSquare(t *testing.T) {
+	squareSize := 8
+	txs := generateRandomTxs(3, 200)
+	blobs := []coretypes.Blob{
+		generateRandomBlobWithNamespace(namespace.ID{2, 2, 2, 2, 2, 2, 2, 2}, 1000),
+		generateRandomBlobWithNamespace(namespace.ID{1, 1, 1, 1, 1, 1, 1, 1}, 100),
+		generateRandomBlobWithNamespace(namespace.ID{1, 1, 1, 1, 1, 1, 1, 1}, 600),
+	}
+
+	shares, err := BuildSquare(TxsToBytes(txs), blobs, squareSize)
+	require.NoError(t, err)
+	require.Len(t, shares, squareSize*squareSize)
+
+	// namespaces must be non-decreasing across the square
+	for i := 1; i < len(shares); i++ {
+		assert.True(t, bytes.Compare(shares[i-1].NamespaceID(), shares[i].NamespaceID()) <= 0)
+	}
+	assert.True(t, shares[len(shares)-1].isTailPadding())
+
+	eds, err := rsmt2d.ComputeExtendedDataSquare(ToBytes(shares), appconsts.DefaultCodec(), rsmt2d.NewDefaultTree)
+	require.NoError(t, err)
+	got, err := merge(eds)
+	require.NoError(t, err)
+	assert.Equal(t, txs, got.Txs)
+	assert.Equal(t, []coretypes.Blob{blobs[1], blobs[2], blobs[0]}, got.Blobs)
+
+	// the blobs provided must not be reordered
+	assert.Equal(t, namespace.ID{2, 2, 2, 2, 2, 2, 2, 2}, blobs[0].NamespaceID)
+}
+
+func TestBuildSquareErrors(t *testing.T) {
+	blob := generateRandomBlobWithNamespace(namespace.ID{1, 1, 1, 1, 1, 1, 1, 1}, 1000)
+
+	_, err := BuildSquare(nil, []coretypes.Blob{blob}, 3)
+	assert.Error(t, err)
+
+	_, err = BuildSquare(nil, []coretypes.Blob{blob}, 1)
+	assert.Error(t, err)
+}
+
+func TestBuildSquareEmpty(t *testing.T) {
+	shares, err := BuildSquare(nil, nil, 1)
+	require.NoError(t, err)
+	require.Len(t, shares, 1)
+	assert.True(t, shares[0].isTailPadding())
+}
+
 func Test_mergeMaps(t *testing.T) {
 	type testCase struct {
 		name   string
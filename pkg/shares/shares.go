@@ -3,7 +3,9 @@ package shares
 import (
 	"bytes"
 	"encoding/binary"
+	"errors"
 	"fmt"
+	"io"
 
 	"github.com/celestiaorg/celestia-app/pkg/appconsts"
 	"github.com/celestiaorg/nmt/namespace"
@@ -12,13 +14,25 @@ import (
 // Share contains the raw share data (including namespace ID).
 type Share struct {
 	data []byte
+	// isParity is true if this share contains erasure coded data from one of
+	// the parity quadrants of an extended data square. The raw data of a parity
+	// share does not begin with a namespace ID so the parity namespace is
+	// implied.
+	isParity bool
 }
 
 func newShare(data []byte) (*Share, error) {
 	if err := validateSize(data); err != nil {
 		return nil, err
 	}
-	return &Share{data}, nil
+	return &Share{data: data}, nil
+}
+
+// ShareView returns a share backed by data without copying it. The returned
+// share aliases data so the caller must not modify data while the share is in
+// use. It returns an error if data is not the size of a share.
+func ShareView(data []byte) (*Share, error) {
+	return newShare(data)
 }
 
 func (s *Share) Validate() error {
@@ -32,11 +46,52 @@ func validateSize(data []byte) error {
 	return nil
 }
 
+// NamespaceID returns the namespace ID of this share. It returns nil if this
+// share is too short to contain a namespace ID.
 func (s *Share) NamespaceID() namespace.ID {
-	if len(s.data) < appconsts.NamespaceSize {
-		panic(fmt.Sprintf("share %s is too short to contain a namespace ID", s))
+	ns, err := s.namespaceIDOrErr()
+	if err != nil {
+		return nil
+	}
+	return ns
+}
+
+// namespaceIDOrErr returns the namespace ID of this share or an error if this
+// share is too short to contain a namespace ID.
+func (s *Share) namespaceIDOrErr() (namespace.ID, error) {
+	if s.isParity {
+		return appconsts.ParitySharesNamespaceID, nil
+	}
+	ns, err := s.sliceRange(0, appconsts.NamespaceSize)
+	if err != nil {
+		return nil, fmt.Errorf("share is too short to contain a namespace ID: %w", err)
+	}
+	return namespace.ID(ns), nil
+}
+
+// sliceRange returns s.data[start:end] or an error if the share data doesn't
+// contain the range [start, end).
+func (s *Share) sliceRange(start, end int) ([]byte, error) {
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("invalid range [%d, %d)", start, end)
+	}
+	if len(s.data) < end {
+		return nil, fmt.Errorf("share %x of length %d does not contain range [%d, %d)", s.data, len(s.data), start, end)
+	}
+	return s.data[start:end], nil
+}
+
+// NamespaceKey returns the namespace ID of this share as a string that is
+// safe to use as a map key. Unlike NamespaceID, the returned key does not
+// alias the underlying share data so later mutations of the share don't
+// affect it.
+func (s *Share) NamespaceKey() (string, error) {
+	ns, err := s.namespaceIDOrErr()
+	if err != nil {
+		return "", err
 	}
-	return namespace.ID(s.data[:appconsts.NamespaceSize])
+	// converting to a string copies the namespace ID out of the share data
+	return string(ns), nil
 }
 
 func (s *Share) Len() int {
@@ -44,14 +99,42 @@ func (s *Share) Len() int {
 }
 
 func (s *Share) InfoByte() (InfoByte, error) {
-	if len(s.data) < appconsts.NamespaceSize+appconsts.ShareInfoBytes {
-		return 0, fmt.Errorf("share %s is too short to contain an info byte", s)
+	unparsed, err := s.rawInfoByte()
+	if err != nil {
+		return 0, err
 	}
-	// the info byte is the first byte after the namespace ID
-	unparsed := s.data[appconsts.NamespaceSize]
 	return ParseInfoByte(unparsed)
 }
 
+// rawInfoByte returns the unparsed info byte of this share. It returns an
+// error if this is a parity share because the erasure coded data of a parity
+// share doesn't contain an info byte.
+func (s *Share) rawInfoByte() (byte, error) {
+	if s.isParity {
+		return 0, errors.New("parity shares do not contain an info byte")
+	}
+	// the info byte is the first byte after the namespace ID
+	unparsed, err := s.sliceRange(appconsts.NamespaceSize, appconsts.NamespaceSize+appconsts.ShareInfoBytes)
+	if err != nil {
+		return 0, fmt.Errorf("share is too short to contain an info byte: %w", err)
+	}
+	return unparsed[0], nil
+}
+
+// InfoFlags returns the fields encoded in the info byte of this share: the
+// share version, the sequence start indicator, and any bits of the info byte
+// that are not assigned to either field. The share version and sequence start
+// indicator occupy every bit of the info byte in the current layout so
+// reservedBits is always 0.
+func (s *Share) InfoFlags() (version uint8, sequenceStart bool, reservedBits byte, err error) {
+	infoByte, err := s.InfoByte()
+	if err != nil {
+		return 0, false, 0, err
+	}
+	reservedBits = byte(infoByte) &^ (ShareVersionMask | SequenceStartBit)
+	return infoByte.Version(), infoByte.IsSequenceStart(), reservedBits, nil
+}
+
 func (s *Share) Version() (uint8, error) {
 	infoByte, err := s.InfoByte()
 	if err != nil {
@@ -73,11 +156,28 @@ func (s *Share) DoesSupportVersions(supportedShareVersions []uint8) error {
 
 // IsSequenceStart returns true if this is the first share in a sequence.
 func (s *Share) IsSequenceStart() (bool, error) {
-	infoByte, err := s.InfoByte()
+	return s.rawSequenceStart()
+}
+
+// rawSequenceStart returns whether the sequence start indicator is set in the
+// info byte of this share without parsing the info byte into an InfoByte.
+func (s *Share) rawSequenceStart() (bool, error) {
+	unparsed, err := s.rawInfoByte()
 	if err != nil {
 		return false, err
 	}
-	return infoByte.IsSequenceStart(), nil
+	return IsSequenceStartByte(unparsed), nil
+}
+
+// IsParityShare returns true if this share contains erasure coded data from
+// one of the parity quadrants of an extended data square. A parity share
+// doesn't contain a header so InfoByte, IsSequenceStart, SequenceLen, RawData,
+// and Header return an error for it. Whether a share is a parity share is not
+// part of its data so it is lost by ToBytes and FromBytes; callers that know
+// the position of a share in an extended data square can use IsParityPosition
+// or ExtendedSquareFromBytes instead.
+func (s *Share) IsParityShare() bool {
+	return s.isParity
 }
 
 // IsCompactShare returns true if this is a compact share.
@@ -99,10 +199,11 @@ func (s *Share) SequenceLen() (sequenceLen uint32, err error) {
 
 	start := appconsts.NamespaceSize + appconsts.ShareInfoBytes
 	end := start + appconsts.SequenceLenBytes
-	if len(s.data) < end {
-		return 0, fmt.Errorf("share %s is too short to contain a sequence length", s)
+	sequenceLenBytes, err := s.sliceRange(start, end)
+	if err != nil {
+		return 0, fmt.Errorf("share is too short to contain a sequence length: %w", err)
 	}
-	return binary.BigEndian.Uint32(s.data[start:end]), nil
+	return binary.BigEndian.Uint32(sequenceLenBytes), nil
 }
 
 // IsPadding returns whether this *share is padding or not.
@@ -142,29 +243,112 @@ func (s *Share) ToBytes() []byte {
 // RawData returns the raw share data. The raw share data does not contain the
 // namespace ID, info byte, sequence length, or reserved bytes.
 func (s *Share) RawData() (rawData []byte, err error) {
-	if len(s.data) < s.rawDataStartIndex() {
-		return rawData, fmt.Errorf("share %s is too short to contain raw data", s)
+	start, err := s.rawDataStartIndex()
+	if err != nil {
+		return rawData, err
 	}
+	rawData, err = s.sliceRange(start, len(s.data))
+	if err != nil {
+		return rawData, fmt.Errorf("share is too short to contain raw data: %w", err)
+	}
+	return rawData, nil
+}
+
+// Header returns the bytes of this share between the namespace ID and the raw
+// data: the info byte, the sequence length (if this is the start of a
+// sequence), and the reserved bytes (if this is a compact share). The bytes
+// returned alias the share data.
+func (s *Share) Header() ([]byte, error) {
+	if s.isParity {
+		return nil, errors.New("parity shares do not contain a header")
+	}
+	end, err := s.rawDataStartIndex()
+	if err != nil {
+		return nil, err
+	}
+	header, err := s.sliceRange(appconsts.NamespaceSize, end)
+	if err != nil {
+		return nil, fmt.Errorf("share is too short to contain a header: %w", err)
+	}
+	return header, nil
+}
 
-	return s.data[s.rawDataStartIndex():], nil
+// RawDataLimited returns at most max bytes of the raw share data. Like
+// RawData, the bytes returned alias the share data.
+func (s *Share) RawDataLimited(max int) ([]byte, error) {
+	if max < 0 {
+		return nil, fmt.Errorf("max %d must not be negative", max)
+	}
+	rawData, err := s.RawData()
+	if err != nil {
+		return nil, err
+	}
+	if len(rawData) > max {
+		rawData = rawData[:max]
+	}
+	return rawData, nil
 }
 
-func (s *Share) rawDataStartIndex() int {
+// PayloadReaderUpTo returns a reader over the first n bytes of the raw share
+// data. The last share of a sequence can be read with n set to the number of
+// bytes remaining in the sequence so that padding after the sequence isn't
+// read. It returns an error if n is negative or exceeds the length of the raw
+// share data.
+func (s *Share) PayloadReaderUpTo(n int) (io.Reader, error) {
+	rawData, err := s.RawData()
+	if err != nil {
+		return nil, err
+	}
+	if n < 0 || n > len(rawData) {
+		return nil, fmt.Errorf("n %d must be between 0 and the raw data length %d", n, len(rawData))
+	}
+	return bytes.NewReader(rawData[:n]), nil
+}
+
+// PayloadStartsWith returns true if the payload of the sequence that starts
+// with this share begins with magic. Only the payload in this share is
+// considered so magic must not be longer than the raw data of this share. The
+// payload excludes any padding after the sequence length so it returns false
+// if the sequence is shorter than magic. It returns an error if this share is
+// not the start of a sequence.
+func (s *Share) PayloadStartsWith(magic []byte) (bool, error) {
 	isStart, err := s.IsSequenceStart()
 	if err != nil {
-		panic(err)
+		return false, err
+	}
+	if !isStart {
+		return false, errors.New("share is not the start of a sequence")
+	}
+	sequenceLen, err := s.SequenceLen()
+	if err != nil {
+		return false, err
+	}
+	rawData, err := s.RawData()
+	if err != nil {
+		return false, err
+	}
+	if len(magic) > len(rawData) {
+		return false, fmt.Errorf("magic of %d bytes is longer than the %d bytes of raw data in the share", len(magic), len(rawData))
+	}
+	if uint64(sequenceLen) < uint64(len(rawData)) {
+		rawData = rawData[:sequenceLen]
+	}
+	return bytes.HasPrefix(rawData, magic), nil
+}
+
+func (s *Share) rawDataStartIndex() (int, error) {
+	isStart, err := s.IsSequenceStart()
+	if err != nil {
+		return 0, err
+	}
+	index := appconsts.NamespaceSize + appconsts.ShareInfoBytes
+	if isStart {
+		index += appconsts.SequenceLenBytes
 	}
-	if isStart && s.IsCompactShare() {
-		return appconsts.NamespaceSize + appconsts.ShareInfoBytes + appconsts.SequenceLenBytes + appconsts.CompactShareReservedBytes
-	} else if isStart && !s.IsCompactShare() {
-		return appconsts.NamespaceSize + appconsts.ShareInfoBytes + appconsts.SequenceLenBytes
-	} else if !isStart && s.IsCompactShare() {
-		return appconsts.NamespaceSize + appconsts.ShareInfoBytes + appconsts.CompactShareReservedBytes
-	} else if !isStart && !s.IsCompactShare() {
-		return appconsts.NamespaceSize + appconsts.ShareInfoBytes
-	} else {
-		panic(fmt.Sprintf("unable to determine the rawDataStartIndex for share %s", s.data))
+	if s.IsCompactShare() {
+		index += appconsts.CompactShareReservedBytes
 	}
+	return index, nil
 }
 
 func ToBytes(shares []Share) (bytes [][]byte) {
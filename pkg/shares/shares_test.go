@@ -2,6 +2,7 @@ package shares
 
 import (
 	"bytes"
+	"io"
 	"testing"
 
 	"github.com/celestiaorg/celestia-app/pkg/appconsts"
@@ -318,3 +319,307 @@ func TestIsPadding(t *testing.T) {
 		})
 	}
 }
+
+func TestInfoFlags(t *testing.T) {
+	type testCase struct {
+		name              string
+		share             Share
+		wantVersion       uint8
+		wantSequenceStart bool
+		wantErr           bool
+	}
+	testCases := []testCase{
+		{
+			name:              "sequence start",
+			share:             shareWithData(nsOne, true, 1, []byte{1}),
+			wantVersion:       appconsts.ShareVersionZero,
+			wantSequenceStart: true,
+		},
+		{
+			name:              "continuation share",
+			share:             shareWithData(nsOne, false, 0, []byte{1}),
+			wantVersion:       appconsts.ShareVersionZero,
+			wantSequenceStart: false,
+		},
+		{
+			name:              "max version",
+			share:             Share{data: []byte{1, 1, 1, 1, 1, 1, 1, 1, 0xFF}},
+			wantVersion:       appconsts.MaxShareVersion,
+			wantSequenceStart: true,
+		},
+		{
+			name:    "no info byte",
+			share:   Share{data: []byte{1, 1, 1, 1, 1, 1, 1, 1}},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			version, sequenceStart, reservedBits, err := tc.share.InfoFlags()
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantVersion, version)
+			assert.Equal(t, tc.wantSequenceStart, sequenceStart)
+			assert.Equal(t, byte(0), reservedBits)
+		})
+	}
+}
+
+func TestNamespaceKey(t *testing.T) {
+	data, _ := zeroPadIfNecessary([]byte{1, 2, 3, 4, 5, 6, 7, 8}, appconsts.ShareSize)
+	share := Share{data: data}
+
+	key, err := share.NamespaceKey()
+	require.NoError(t, err)
+	assert.Equal(t, string([]byte{1, 2, 3, 4, 5, 6, 7, 8}), key)
+
+	// mutating the share must not affect a previously returned key
+	data[0] = 0xFF
+	assert.Equal(t, string([]byte{1, 2, 3, 4, 5, 6, 7, 8}), key)
+
+	parity := Share{data: bytes.Repeat([]byte{0}, appconsts.ShareSize), isParity: true}
+	key, err = parity.NamespaceKey()
+	require.NoError(t, err)
+	assert.Equal(t, string(appconsts.ParitySharesNamespaceID), key)
+
+	tooShort := Share{data: []byte{1, 2, 3}}
+	_, err = tooShort.NamespaceKey()
+	assert.Error(t, err)
+}
+
+func TestAccessorsOnShortShares(t *testing.T) {
+	shares := []Share{
+		{data: nil},
+		{data: []byte{1, 2, 3}},
+		{data: bytes.Repeat([]byte{1}, appconsts.NamespaceSize)},
+		// a sequence start share that is too short to contain a sequence length
+		{data: append(bytes.Repeat([]byte{1}, appconsts.NamespaceSize), 1, 0)},
+	}
+	for _, share := range shares {
+		assert.NotPanics(t, func() {
+			share.NamespaceID()
+			_, _ = share.InfoByte()
+			_, _ = share.SequenceLen()
+			_, _ = share.RawData()
+			_, _ = share.IsPadding()
+			share.IsCompactShare()
+		})
+	}
+
+	tooShort := Share{data: []byte{1, 2, 3}}
+	assert.Nil(t, tooShort.NamespaceID())
+	_, err := tooShort.InfoByte()
+	assert.Error(t, err)
+	_, err = tooShort.RawData()
+	assert.Error(t, err)
+
+	noSequenceLen := shares[3]
+	_, err = noSequenceLen.SequenceLen()
+	assert.Error(t, err)
+	_, err = noSequenceLen.RawData()
+	assert.Error(t, err)
+}
+
+func TestSliceRange(t *testing.T) {
+	share := Share{data: []byte{1, 2, 3, 4}}
+
+	got, err := share.sliceRange(1, 3)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{2, 3}, got)
+
+	_, err = share.sliceRange(2, 5)
+	assert.Error(t, err)
+	_, err = share.sliceRange(-1, 2)
+	assert.Error(t, err)
+	_, err = share.sliceRange(3, 2)
+	assert.Error(t, err)
+}
+
+func TestShareView(t *testing.T) {
+	data := bytes.Repeat([]byte{1}, appconsts.ShareSize)
+	share, err := ShareView(data)
+	require.NoError(t, err)
+	assert.Equal(t, data, share.ToBytes())
+
+	// the share aliases the data provided
+	data[0] = 2
+	assert.Equal(t, byte(2), share.ToBytes()[0])
+
+	_, err = ShareView(data[:appconsts.ShareSize-1])
+	assert.Error(t, err)
+}
+
+func TestRawDataLimited(t *testing.T) {
+	share := shareWithData(nsOne, true, 3, []byte{1, 2, 3})
+
+	got, err := share.RawDataLimited(2)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1, 2}, got)
+
+	got, err = share.RawDataLimited(appconsts.ShareSize)
+	require.NoError(t, err)
+	assert.Len(t, got, appconsts.FirstSparseShareContentSize)
+
+	got, err = share.RawDataLimited(0)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	_, err = share.RawDataLimited(-1)
+	assert.Error(t, err)
+
+	tooShort := Share{data: []byte{1}}
+	_, err = tooShort.RawDataLimited(1)
+	assert.Error(t, err)
+}
+
+func TestPayloadReaderUpTo(t *testing.T) {
+	share := shareWithData(nsOne, false, 0, []byte{1, 2, 3})
+
+	reader, err := share.PayloadReaderUpTo(2)
+	require.NoError(t, err)
+	got, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1, 2}, got)
+
+	reader, err = share.PayloadReaderUpTo(appconsts.ContinuationSparseShareContentSize)
+	require.NoError(t, err)
+	got, err = io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Len(t, got, appconsts.ContinuationSparseShareContentSize)
+
+	_, err = share.PayloadReaderUpTo(appconsts.ContinuationSparseShareContentSize + 1)
+	assert.Error(t, err)
+
+	_, err = share.PayloadReaderUpTo(-1)
+	assert.Error(t, err)
+}
+
+func TestHeader(t *testing.T) {
+	parity, err := NewParityShare(make([]byte, appconsts.ShareSize))
+	require.NoError(t, err)
+
+	type testCase struct {
+		name    string
+		share   Share
+		want    []byte
+		wantErr bool
+	}
+	testCases := []testCase{
+		{
+			name:  "sparse start share",
+			share: shareWithData(nsOne, true, 3, []byte{1, 2, 3}),
+			want:  []byte{1, 0, 0, 0, 3},
+		},
+		{
+			name:  "sparse continuation share",
+			share: shareWithData(nsOne, false, 0, []byte{1, 2, 3}),
+			want:  []byte{0},
+		},
+		{
+			name:  "compact start share",
+			share: shareWithData(appconsts.TxNamespaceID, true, 3, []byte{0, 0, 0, 0, 1, 2, 3}),
+			want:  []byte{1, 0, 0, 0, 3, 0, 0, 0, 0},
+		},
+		{
+			name:  "compact continuation share",
+			share: shareWithData(appconsts.TxNamespaceID, false, 0, []byte{0, 0, 0, 0, 1, 2, 3}),
+			want:  []byte{0, 0, 0, 0, 0},
+		},
+		{
+			name:    "parity share",
+			share:   parity,
+			wantErr: true,
+		},
+		{
+			name:    "too short",
+			share:   Share{data: append(bytes.Repeat([]byte{1}, appconsts.NamespaceSize), 1, 0)},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.share.Header()
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestPayloadStartsWith(t *testing.T) {
+	png := []byte{0x89, 'P', 'N', 'G'}
+	start := shareWithData(nsOne, true, 10, append(append([]byte{}, png...), 1, 2, 3, 4, 5, 6))
+
+	type testCase struct {
+		name    string
+		share   Share
+		magic   []byte
+		want    bool
+		wantErr bool
+	}
+	testCases := []testCase{
+		{name: "matching magic", share: start, magic: png, want: true},
+		{name: "different magic", share: start, magic: []byte("{"), want: false},
+		{name: "empty magic", share: start, magic: nil, want: true},
+		{name: "sequence shorter than magic", share: shareWithData(nsOne, true, 2, png), magic: png, want: false},
+		{name: "compact start share", share: shareWithData(appconsts.TxNamespaceID, true, 4, append([]byte{0, 0, 0, 0}, png...)), magic: png, want: true},
+		{name: "magic longer than the raw data", share: start, magic: bytes.Repeat([]byte{1}, appconsts.ShareSize), wantErr: true},
+		{name: "continuation share", share: shareWithData(nsOne, false, 0, png), magic: png, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.share.PayloadStartsWith(tc.magic)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestRawSequenceStart(t *testing.T) {
+	start := shareWithData(nsOne, true, 1, []byte{1})
+	got, err := start.rawSequenceStart()
+	require.NoError(t, err)
+	assert.True(t, got)
+
+	continuation := shareWithData(nsOne, false, 0, []byte{1})
+	got, err = continuation.rawSequenceStart()
+	require.NoError(t, err)
+	assert.False(t, got)
+
+	tooShort := Share{data: nsOne}
+	_, err = tooShort.rawSequenceStart()
+	assert.Error(t, err)
+}
+
+func BenchmarkSequenceStart(b *testing.B) {
+	share := shareWithData(nsOne, true, 1, []byte{1})
+	b.Run("InfoByte", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			infoByte, err := share.InfoByte()
+			if err != nil || !infoByte.IsSequenceStart() {
+				b.Fatal("expected a sequence start")
+			}
+		}
+	})
+	b.Run("rawSequenceStart", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			isStart, err := share.rawSequenceStart()
+			if err != nil || !isStart {
+				b.Fatal("expected a sequence start")
+			}
+		}
+	})
+}
@@ -0,0 +1,344 @@
+package shares
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/nmt/namespace"
+)
+
+// MaxSquareSize is the maximum width of an original data square accepted by the
+// functions in this package that take a square size.
+const MaxSquareSize = appconsts.DefaultMaxSquareSize
+
+// ValidateSquareSize returns an error if squareSize is not a power of two or
+// exceeds MaxSquareSize.
+func ValidateSquareSize(squareSize int) error {
+	if squareSize <= 0 || !IsPowerOfTwo(squareSize) {
+		return fmt.Errorf("square size %d must be a power of two", squareSize)
+	}
+	if squareSize > MaxSquareSize {
+		return fmt.Errorf("square size %d exceeds the maximum square size %d", squareSize, MaxSquareSize)
+	}
+	return nil
+}
+
+// IsRowStart returns true if the share at flatIndex is the first share of a
+// row in a square of width squareSize. Shares are indexed in row-major order.
+// It returns false if flatIndex is not a valid index in the square.
+func IsRowStart(flatIndex, squareSize int) bool {
+	if !isValidFlatIndex(flatIndex, squareSize) {
+		return false
+	}
+	return flatIndex%squareSize == 0
+}
+
+// IsRowEnd returns true if the share at flatIndex is the last share of a row in
+// a square of width squareSize. Shares are indexed in row-major order. It
+// returns false if flatIndex is not a valid index in the square.
+func IsRowEnd(flatIndex, squareSize int) bool {
+	if !isValidFlatIndex(flatIndex, squareSize) {
+		return false
+	}
+	return flatIndex%squareSize == squareSize-1
+}
+
+// IsLastShare returns true if flatIndex is the index of the last share in a
+// square of width squareSize. It returns false if flatIndex is not a valid
+// index in the square.
+func IsLastShare(flatIndex, squareSize int) bool {
+	if !isValidFlatIndex(flatIndex, squareSize) {
+		return false
+	}
+	return flatIndex == squareSize*squareSize-1
+}
+
+// TrailingPaddingCount returns the number of contiguous tail padding shares at
+// the end of shares.
+func TrailingPaddingCount(shares []Share) (int, error) {
+	count := 0
+	for i := len(shares) - 1; i >= 0; i-- {
+		if err := shares[i].Validate(); err != nil {
+			return 0, err
+		}
+		if !shares[i].isTailPadding() {
+			break
+		}
+		count++
+	}
+	return count, nil
+}
+
+// BlobRowSpan returns the indexes of the first and last rows occupied by a
+// blob that starts at share index blobStartIndex and occupies blobShareCount
+// shares in a square of width squareSize. It returns an error if the blob
+// doesn't fit in the square.
+func BlobRowSpan(blobStartIndex, blobShareCount, squareSize int) (firstRow, lastRow int, err error) {
+	if err := ValidateSquareSize(squareSize); err != nil {
+		return 0, 0, err
+	}
+	if blobShareCount <= 0 {
+		return 0, 0, fmt.Errorf("blob share count %d must be positive", blobShareCount)
+	}
+	blobEndIndex := blobStartIndex + blobShareCount - 1
+	if !isValidFlatIndex(blobStartIndex, squareSize) || !isValidFlatIndex(blobEndIndex, squareSize) {
+		return 0, 0, fmt.Errorf("blob with start index %d and share count %d does not fit in a square of size %d", blobStartIndex, blobShareCount, squareSize)
+	}
+	return blobStartIndex / squareSize, blobEndIndex / squareSize, nil
+}
+
+// SparseRegion returns the shares of the data square provided that belong to
+// blobs. The returned shares begin with the first sparse share and end with
+// the last sparse share that isn't padding so the compact shares, the reserved
+// padding after them, and the tail padding at the end of the square are
+// excluded. It returns an error if the shares are not in the canonical order:
+// compact shares, reserved padding, sparse shares, then tail padding.
+func SparseRegion(shares []Share) ([]Share, error) {
+	start, end := -1, -1
+	seenTailPadding := false
+	for i, share := range shares {
+		if err := share.Validate(); err != nil {
+			return nil, err
+		}
+		switch {
+		case share.isTailPadding():
+			seenTailPadding = true
+		case seenTailPadding:
+			return nil, fmt.Errorf("share %d is not tail padding but follows tail padding", i)
+		case share.IsCompactShare():
+			if start != -1 {
+				return nil, fmt.Errorf("compact share %d follows sparse shares", i)
+			}
+		case share.isReservedPadding():
+			if start != -1 {
+				return nil, fmt.Errorf("reserved padding share %d follows sparse shares", i)
+			}
+		default:
+			if start == -1 {
+				start = i
+			}
+			isPadding, err := share.IsPadding()
+			if err != nil {
+				return nil, err
+			}
+			if !isPadding {
+				end = i + 1
+			}
+		}
+	}
+	if end <= start {
+		return []Share{}, nil
+	}
+	return shares[start:end], nil
+}
+
+// FirstSparseShareIndex returns the index of the first share in shares that
+// belongs to a user blob, i.e. the first sparse share after the compact region
+// of a data square that is not padding. It returns len(shares) if shares don't
+// contain a blob. It returns an error if a compact share follows a share that
+// is not compact because the compact shares must be a contiguous prefix of the
+// square.
+func FirstSparseShareIndex(shares []Share) (int, error) {
+	end, err := compactRegionEnd(shares)
+	if err != nil {
+		return 0, err
+	}
+	for i := end; i < len(shares); i++ {
+		isPadding, err := shares[i].IsPadding()
+		if err != nil {
+			return 0, err
+		}
+		if !isPadding {
+			return i, nil
+		}
+	}
+	return len(shares), nil
+}
+
+// compactRegionEnd returns the index of the first share in shares that is not
+// a compact share, i.e. the index where the compact region of a data square
+// ends. It returns len(shares) if every share is a compact share. It returns an
+// error if a compact share follows a share that is not compact.
+func compactRegionEnd(shares []Share) (int, error) {
+	end := len(shares)
+	for i, share := range shares {
+		if err := share.Validate(); err != nil {
+			return 0, err
+		}
+		isCompact := share.IsCompactShare()
+		if isCompact && end != len(shares) {
+			return 0, fmt.Errorf("compact share %d follows non-compact share %d", i, end)
+		}
+		if !isCompact && end == len(shares) {
+			end = i
+		}
+	}
+	return end, nil
+}
+
+// SamplingCoordinates returns the (row, column) coordinates of every share in
+// the namespace ns of a square of width squareSize. The shares of a namespace
+// must be contiguous so it returns an error if shares of ns are interleaved
+// with shares of another namespace.
+func SamplingCoordinates(shares []Share, ns namespace.ID, squareSize int) ([][2]int, error) {
+	if err := ValidateSquareSize(squareSize); err != nil {
+		return nil, err
+	}
+	if len(shares) != squareSize*squareSize {
+		return nil, fmt.Errorf("square of width %d must contain %d shares, got %d", squareSize, squareSize*squareSize, len(shares))
+	}
+
+	coordinates := [][2]int{}
+	end := -1
+	for i, share := range shares {
+		shareNamespace, err := share.namespaceIDOrErr()
+		if err != nil {
+			return nil, err
+		}
+		if !shareNamespace.Equal(ns) {
+			continue
+		}
+		if end != -1 && end != i {
+			return nil, fmt.Errorf("share %d of namespace %x is not contiguous with the previous share of the namespace", i, ns)
+		}
+		row, col := rowCol(i, squareSize)
+		coordinates = append(coordinates, [2]int{row, col})
+		end = i + 1
+	}
+	return coordinates, nil
+}
+
+// IsMinimalSquare returns true if squareSize is the smallest square size that
+// can contain the compact shares and blobs in shares when the blobs are laid
+// out according to the non-interactive default rules. Padding shares are not
+// counted because the amount of padding needed depends on the square size. It
+// returns an error if the compact shares and blobs don't fit in squareSize
+// itself because then shares is not a valid data square.
+func IsMinimalSquare(shares []Share, squareSize int) (bool, error) {
+	if err := ValidateSquareSize(squareSize); err != nil {
+		return false, err
+	}
+	if len(shares) != squareSize*squareSize {
+		return false, fmt.Errorf("square of width %d must contain %d shares, got %d", squareSize, squareSize*squareSize, len(shares))
+	}
+
+	compactShareCount, err := compactRegionEnd(shares)
+	if err != nil {
+		return false, err
+	}
+	blobShareLens, err := blobShareLens(shares[compactShareCount:])
+	if err != nil {
+		return false, err
+	}
+
+	// the shares used by the blobs aren't needed because only whether the
+	// blobs fit is checked
+	if fits, _ := FitsInSquare(compactShareCount, squareSize, blobShareLens...); !fits {
+		return false, fmt.Errorf("compact shares and blobs don't fit in a square of width %d according to the non-interactive default rules", squareSize)
+	}
+	for smallerSquareSize := appconsts.DefaultMinSquareSize; smallerSquareSize < squareSize; smallerSquareSize *= 2 {
+		if fits, _ := FitsInSquare(compactShareCount, smallerSquareSize, blobShareLens...); fits {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// blobShareLens returns the number of shares occupied by each blob in shares.
+// Padding shares are skipped.
+func blobShareLens(shares []Share) ([]int, error) {
+	lens := []int{}
+	for i := 0; i < len(shares); i++ {
+		isPadding, err := shares[i].IsPadding()
+		if err != nil {
+			return nil, err
+		}
+		if isPadding {
+			continue
+		}
+		isSequenceStart, err := shares[i].IsSequenceStart()
+		if err != nil {
+			return nil, err
+		}
+		if !isSequenceStart {
+			return nil, fmt.Errorf("share %d is a continuation share that doesn't follow a sequence start", i)
+		}
+		sharesNeeded, err := numberOfSharesNeeded(shares[i])
+		if err != nil {
+			return nil, err
+		}
+		if i+sharesNeeded > len(shares) {
+			return nil, fmt.Errorf("blob starting at share %d needs %d shares but only %d shares remain", i, sharesNeeded, len(shares)-i)
+		}
+		lens = append(lens, sharesNeeded)
+		i += sharesNeeded - 1
+	}
+	return lens, nil
+}
+
+// rowCol returns the row and column of the share at flatIndex in a square of
+// width squareSize. Shares are indexed in row-major order.
+func rowCol(flatIndex, squareSize int) (row, col int) {
+	return flatIndex / squareSize, flatIndex % squareSize
+}
+
+// ValidateCanonicalNamespaceOrder returns an error if the shares of a data
+// square are not in the canonical order: transaction shares, PayForBlob
+// shares, reserved padding, blob shares in ascending namespace order, then
+// tail padding. Namespace padding may follow a blob in the same namespace. The
+// error identifies the first share that is out of order.
+func ValidateCanonicalNamespaceOrder(shares []Share) error {
+	prevRank := -1
+	var prevClass ShareClass
+	var prevNamespace []byte
+	for i := range shares {
+		if err := shares[i].Validate(); err != nil {
+			return err
+		}
+		class, err := shares[i].Class()
+		if err != nil {
+			return err
+		}
+		rank, ok := canonicalRank(class)
+		if !ok {
+			return fmt.Errorf("share %d is a %s share which is not allowed in a data square", i, class)
+		}
+		ns := shares[i].NamespaceID()
+		if rank < prevRank {
+			return fmt.Errorf("share %d is a %s share but follows a %s share: expected the order tx, pay for blob, reserved padding, blob, tail padding", i, class, prevClass)
+		}
+		if rank == prevRank && bytes.Compare(ns, prevNamespace) < 0 {
+			return fmt.Errorf("share %d has namespace %x but follows a share with namespace %x: expected blob namespaces in ascending order", i, ns, prevNamespace)
+		}
+		prevRank, prevClass, prevNamespace = rank, class, ns
+	}
+	return nil
+}
+
+// canonicalRank returns the position of shares of class in the canonical
+// order of a data square. Blob shares and namespace padding shares share a
+// rank because they are ordered by namespace. It returns false for classes
+// that are not allowed in a data square.
+func canonicalRank(class ShareClass) (int, bool) {
+	switch class {
+	case TxShareClass:
+		return 0, true
+	case PayForBlobShareClass:
+		return 1, true
+	case ReservedPaddingShareClass:
+		return 2, true
+	case BlobShareClass, NamespacePaddingShareClass:
+		return 3, true
+	case TailPaddingShareClass:
+		return 4, true
+	default:
+		return 0, false
+	}
+}
+
+// isValidFlatIndex returns true if flatIndex is the index of a share in a
+// square of width squareSize.
+func isValidFlatIndex(flatIndex, squareSize int) bool {
+	return squareSize > 0 && flatIndex >= 0 && flatIndex < squareSize*squareSize
+}
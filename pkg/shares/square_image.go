@@ -0,0 +1,75 @@
+package shares
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+)
+
+var (
+	// compactShareColor is the color of transaction and PayForBlob shares in
+	// images rendered by RenderSquarePNG.
+	compactShareColor = color.RGBA{R: 0x00, G: 0x00, B: 0x00, A: 0xFF}
+	// paddingShareColor is the color of reserved, namespace, and tail padding
+	// shares in images rendered by RenderSquarePNG.
+	paddingShareColor = color.RGBA{R: 0xFF, G: 0xFF, B: 0xFF, A: 0xFF}
+	// parityShareColor is the color of parity shares in images rendered by
+	// RenderSquarePNG.
+	parityShareColor = color.RGBA{R: 0x80, G: 0x80, B: 0x80, A: 0xFF}
+)
+
+// RenderSquarePNG writes a PNG image of the data square shares of width
+// squareSize to w. Each share is drawn as one pixel in row-major order.
+// Compact shares, padding shares, and parity shares each have a distinct color
+// and every other share is colored by a hash of its namespace so that the
+// shares of a namespace have the same color.
+func RenderSquarePNG(w io.Writer, shares []Share, squareSize int) error {
+	if err := ValidateSquareSize(squareSize); err != nil {
+		return err
+	}
+	if len(shares) != squareSize*squareSize {
+		return fmt.Errorf("square of width %d must contain %d shares, got %d", squareSize, squareSize*squareSize, len(shares))
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, squareSize, squareSize))
+	for i, share := range shares {
+		c, err := shareColor(share)
+		if err != nil {
+			return err
+		}
+		row, col := rowCol(i, squareSize)
+		img.SetRGBA(col, row, c)
+	}
+	return png.Encode(w, img)
+}
+
+// shareColor returns the color of share in images rendered by
+// RenderSquarePNG.
+func shareColor(share Share) (color.RGBA, error) {
+	if share.IsParityShare() {
+		return parityShareColor, nil
+	}
+	if err := share.Validate(); err != nil {
+		return color.RGBA{}, err
+	}
+	if share.IsCompactShare() {
+		return compactShareColor, nil
+	}
+	isPadding, err := share.IsPadding()
+	if err != nil {
+		return color.RGBA{}, err
+	}
+	if isPadding {
+		return paddingShareColor, nil
+	}
+	return namespaceColor(share.NamespaceID()), nil
+}
+
+// namespaceColor returns an opaque color derived from a hash of ns.
+func namespaceColor(ns []byte) color.RGBA {
+	sum := sha256.Sum256(ns)
+	return color.RGBA{R: sum[0], G: sum[1], B: sum[2], A: 0xFF}
+}
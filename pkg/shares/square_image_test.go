@@ -0,0 +1,52 @@
+package shares
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/celestiaorg/nmt/namespace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coretypes "github.com/tendermint/tendermint/types"
+)
+
+func TestRenderSquarePNG(t *testing.T) {
+	nsTwo := namespace.ID{2, 2, 2, 2, 2, 2, 2, 2}
+	txs := TxsToBytes(generateRandomTxs(2, 100))
+	blobs := []coretypes.Blob{
+		generateRandomBlobWithNamespace(nsOne, 100),
+		generateRandomBlobWithNamespace(nsTwo, 100),
+	}
+	squareSize := 4
+	shares, err := BuildSquare(txs, blobs, squareSize)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, RenderSquarePNG(&buf, shares, squareSize))
+	img, err := png.Decode(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, squareSize, img.Bounds().Dx())
+	assert.Equal(t, squareSize, img.Bounds().Dy())
+
+	// the tx share occupies index 0, the blobs occupy indexes 1 and 2, and
+	// the rest of the square is tail padding.
+	want := []color.RGBA{compactShareColor, namespaceColor(nsOne), namespaceColor(nsTwo)}
+	for len(want) < squareSize*squareSize {
+		want = append(want, paddingShareColor)
+	}
+	for i, c := range want {
+		row, col := rowCol(i, squareSize)
+		assert.Equal(t, color.RGBAModel.Convert(c), color.RGBAModel.Convert(img.At(col, row)), "share %d", i)
+	}
+
+	parity, err := NewParityShare(shares[1].data)
+	require.NoError(t, err)
+	c, err := shareColor(parity)
+	require.NoError(t, err)
+	assert.Equal(t, parityShareColor, c)
+
+	assert.Error(t, RenderSquarePNG(&buf, shares[:15], squareSize))
+	assert.Error(t, RenderSquarePNG(&buf, shares, 3))
+}
@@ -0,0 +1,451 @@
+package shares
+
+import (
+	"math"
+	"testing"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/nmt/namespace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coretypes "github.com/tendermint/tendermint/types"
+)
+
+func TestIsRowStartAndEnd(t *testing.T) {
+	type testCase struct {
+		name         string
+		flatIndex    int
+		squareSize   int
+		wantRowStart bool
+		wantRowEnd   bool
+	}
+	testCases := []testCase{
+		{name: "only share in square", flatIndex: 0, squareSize: 1, wantRowStart: true, wantRowEnd: true},
+		{name: "first share", flatIndex: 0, squareSize: 4, wantRowStart: true, wantRowEnd: false},
+		{name: "middle of first row", flatIndex: 1, squareSize: 4, wantRowStart: false, wantRowEnd: false},
+		{name: "end of first row", flatIndex: 3, squareSize: 4, wantRowStart: false, wantRowEnd: true},
+		{name: "start of second row", flatIndex: 4, squareSize: 4, wantRowStart: true, wantRowEnd: false},
+		{name: "last share", flatIndex: 15, squareSize: 4, wantRowStart: false, wantRowEnd: true},
+		{name: "index past end of square", flatIndex: 16, squareSize: 4, wantRowStart: false, wantRowEnd: false},
+		{name: "negative index", flatIndex: -4, squareSize: 4, wantRowStart: false, wantRowEnd: false},
+		{name: "zero square size", flatIndex: 0, squareSize: 0, wantRowStart: false, wantRowEnd: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.wantRowStart, IsRowStart(tc.flatIndex, tc.squareSize))
+			assert.Equal(t, tc.wantRowEnd, IsRowEnd(tc.flatIndex, tc.squareSize))
+		})
+	}
+}
+
+func TestIsLastShare(t *testing.T) {
+	assert.True(t, IsLastShare(0, 1))
+	assert.True(t, IsLastShare(15, 4))
+	assert.False(t, IsLastShare(14, 4))
+	assert.False(t, IsLastShare(0, 4))
+	assert.False(t, IsLastShare(16, 4))
+	assert.False(t, IsLastShare(-1, 4))
+	assert.False(t, IsLastShare(0, 0))
+}
+
+func TestTrailingPaddingCount(t *testing.T) {
+	data := shareWithData(nsOne, true, 1, []byte{1})
+	tail, err := TailPaddingShare()
+	require.NoError(t, err)
+
+	type testCase struct {
+		name    string
+		shares  []Share
+		want    int
+		wantErr bool
+	}
+	testCases := []testCase{
+		{name: "no shares", shares: nil, want: 0},
+		{name: "no tail padding", shares: []Share{data, data}, want: 0},
+		{name: "only tail padding", shares: []Share{tail, tail}, want: 2},
+		{name: "data followed by tail padding", shares: []Share{data, tail, tail, tail}, want: 3},
+		{name: "tail padding that isn't trailing", shares: []Share{tail, data, tail}, want: 1},
+		{name: "invalid share", shares: []Share{data, {data: []byte{1}}}, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := TrailingPaddingCount(tc.shares)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestBlobRowSpan(t *testing.T) {
+	type testCase struct {
+		name           string
+		blobStartIndex int
+		blobShareCount int
+		squareSize     int
+		wantFirstRow   int
+		wantLastRow    int
+		wantErr        bool
+	}
+	testCases := []testCase{
+		{name: "one share", blobStartIndex: 0, blobShareCount: 1, squareSize: 4, wantFirstRow: 0, wantLastRow: 0},
+		{name: "fills the first row exactly", blobStartIndex: 0, blobShareCount: 4, squareSize: 4, wantFirstRow: 0, wantLastRow: 0},
+		{name: "ends at a row boundary", blobStartIndex: 2, blobShareCount: 6, squareSize: 4, wantFirstRow: 0, wantLastRow: 1},
+		{name: "spills into the next row", blobStartIndex: 2, blobShareCount: 3, squareSize: 4, wantFirstRow: 0, wantLastRow: 1},
+		{name: "spans three rows", blobStartIndex: 3, blobShareCount: 6, squareSize: 4, wantFirstRow: 0, wantLastRow: 2},
+		{name: "fills the whole square", blobStartIndex: 0, blobShareCount: 16, squareSize: 4, wantFirstRow: 0, wantLastRow: 3},
+		{name: "exceeds the square", blobStartIndex: 12, blobShareCount: 5, squareSize: 4, wantErr: true},
+		{name: "starts outside the square", blobStartIndex: 16, blobShareCount: 1, squareSize: 4, wantErr: true},
+		{name: "negative start", blobStartIndex: -1, blobShareCount: 2, squareSize: 4, wantErr: true},
+		{name: "zero share count", blobStartIndex: 0, blobShareCount: 0, squareSize: 4, wantErr: true},
+		{name: "zero square size", blobStartIndex: 0, blobShareCount: 1, squareSize: 0, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			firstRow, lastRow, err := BlobRowSpan(tc.blobStartIndex, tc.blobShareCount, tc.squareSize)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantFirstRow, firstRow)
+			assert.Equal(t, tc.wantLastRow, lastRow)
+		})
+	}
+}
+
+func TestSparseRegion(t *testing.T) {
+	nsTwo := namespace.ID{2, 2, 2, 2, 2, 2, 2, 2}
+	txs := TxsToBytes(generateRandomTxs(2, 100))
+	blobs := []coretypes.Blob{
+		generateRandomBlobWithNamespace(nsOne, 100),
+		generateRandomBlobWithNamespace(nsTwo, 1000),
+	}
+	shares, err := BuildSquare(txs, blobs, 4)
+	require.NoError(t, err)
+
+	// the tx share occupies index 0, the first blob occupies index 1, and the
+	// second blob occupies indexes 2 and 3.
+	got, err := SparseRegion(shares)
+	require.NoError(t, err)
+	assert.Equal(t, shares[1:4], got)
+
+	parsed, err := parseSparseShares(got, appconsts.SupportedShareVersions)
+	require.NoError(t, err)
+	assert.Equal(t, blobs, parsed)
+}
+
+func TestSparseRegionNoBlobs(t *testing.T) {
+	shares, err := BuildSquare(TxsToBytes(generateRandomTxs(2, 100)), nil, 2)
+	require.NoError(t, err)
+
+	got, err := SparseRegion(shares)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestSparseRegionErrors(t *testing.T) {
+	compact := shareWithData(appconsts.TxNamespaceID, true, 1, []byte{1})
+	sparse := shareWithData(nsOne, true, 1, []byte{1})
+	reservedPadding, err := ReservedPaddingShare()
+	require.NoError(t, err)
+	tailPadding, err := TailPaddingShare()
+	require.NoError(t, err)
+
+	type testCase struct {
+		name   string
+		shares []Share
+	}
+	testCases := []testCase{
+		{name: "compact share after sparse share", shares: []Share{sparse, compact}},
+		{name: "reserved padding after sparse share", shares: []Share{compact, sparse, reservedPadding}},
+		{name: "sparse share after tail padding", shares: []Share{sparse, tailPadding, sparse}},
+		{name: "invalid share", shares: []Share{{data: []byte{1}}}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := SparseRegion(tc.shares)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestFirstSparseShareIndex(t *testing.T) {
+	tx := shareWithData(appconsts.TxNamespaceID, true, 1, []byte{1})
+	pfb := shareWithData(appconsts.PayForBlobNamespaceID, true, 1, []byte{1})
+	blob := shareWithData(nsOne, true, 1, []byte{1})
+	reserved, err := ReservedPaddingShare()
+	require.NoError(t, err)
+	tail, err := TailPaddingShare()
+	require.NoError(t, err)
+	namespacePadding, err := NamespacePaddingShare(nsOne)
+	require.NoError(t, err)
+
+	type testCase struct {
+		name    string
+		shares  []Share
+		want    int
+		wantErr bool
+	}
+	testCases := []testCase{
+		{name: "no shares", shares: nil, want: 0},
+		{name: "only compact shares", shares: []Share{tx, pfb}, want: 2},
+		{name: "only blob shares", shares: []Share{blob, tail}, want: 0},
+		{name: "compact shares followed by blob shares", shares: []Share{tx, pfb, blob, tail}, want: 2},
+		{name: "compact shares followed by reserved padding", shares: []Share{tx, reserved, blob}, want: 2},
+		{name: "compact shares followed by namespace padding", shares: []Share{tx, namespacePadding, blob}, want: 2},
+		{name: "no blob shares", shares: []Share{tx, reserved, tail}, want: 3},
+		{name: "only padding", shares: []Share{reserved, tail}, want: 2},
+		{name: "compact share after blob share", shares: []Share{tx, blob, pfb}, wantErr: true},
+		{name: "invalid share", shares: []Share{tx, {data: []byte{1}}}, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := FirstSparseShareIndex(tc.shares)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestSamplingCoordinates(t *testing.T) {
+	nsTwo := namespace.ID{0, 0, 0, 0, 0, 0, 0, 2}
+	tx := shareWithData(appconsts.TxNamespaceID, true, 1, []byte{1})
+	one := shareWithData(nsOne, true, 1, []byte{1})
+	two := shareWithData(nsTwo, true, 1, []byte{1})
+	tail, err := TailPaddingShare()
+	require.NoError(t, err)
+
+	square := []Share{
+		tx, one, one, one,
+		one, two, two, tail,
+		tail, tail, tail, tail,
+		tail, tail, tail, tail,
+	}
+
+	type testCase struct {
+		name       string
+		shares     []Share
+		ns         namespace.ID
+		squareSize int
+		want       [][2]int
+		wantErr    bool
+	}
+	testCases := []testCase{
+		{
+			name:       "namespace that spans two rows",
+			shares:     square,
+			ns:         nsOne,
+			squareSize: 4,
+			want:       [][2]int{{0, 1}, {0, 2}, {0, 3}, {1, 0}},
+		},
+		{
+			name:       "namespace in one row",
+			shares:     square,
+			ns:         nsTwo,
+			squareSize: 4,
+			want:       [][2]int{{1, 1}, {1, 2}},
+		},
+		{
+			name:       "absent namespace",
+			shares:     square,
+			ns:         namespace.ID{0, 0, 0, 0, 0, 0, 0, 3},
+			squareSize: 4,
+			want:       [][2]int{},
+		},
+		{
+			name:       "non contiguous namespace",
+			shares:     []Share{one, two, one, tail},
+			ns:         nsOne,
+			squareSize: 2,
+			wantErr:    true,
+		},
+		{
+			name:       "wrong number of shares",
+			shares:     square[:15],
+			ns:         nsOne,
+			squareSize: 4,
+			wantErr:    true,
+		},
+		{
+			name:       "invalid square size",
+			shares:     nil,
+			ns:         nsOne,
+			squareSize: 0,
+			wantErr:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := SamplingCoordinates(tc.shares, tc.ns, tc.squareSize)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestIsMinimalSquare(t *testing.T) {
+	txs := TxsToBytes(generateRandomTxs(1, 100))
+	blobs := []coretypes.Blob{generateRandomBlobWithNamespace(nsOne, 100)}
+
+	type testCase struct {
+		name       string
+		txs        [][]byte
+		blobs      []coretypes.Blob
+		squareSize int
+		want       bool
+	}
+	testCases := []testCase{
+		{name: "empty square of width one", squareSize: 1, want: true},
+		{name: "empty square of width two", squareSize: 2, want: false},
+		{name: "tx and blob in a square of width two", txs: txs, blobs: blobs, squareSize: 2, want: true},
+		{name: "tx and blob in a square of width four", txs: txs, blobs: blobs, squareSize: 4, want: false},
+		{name: "large blob", blobs: []coretypes.Blob{generateRandomBlobWithNamespace(nsOne, 10*appconsts.ShareSize)}, squareSize: 4, want: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			square, err := BuildSquare(tc.txs, tc.blobs, tc.squareSize)
+			require.NoError(t, err)
+			got, err := IsMinimalSquare(square, tc.squareSize)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	t.Run("wrong number of shares", func(t *testing.T) {
+		_, err := IsMinimalSquare([]Share{shareWithData(nsOne, true, 1, []byte{1})}, 2)
+		assert.Error(t, err)
+	})
+
+	t.Run("square size not a power of two", func(t *testing.T) {
+		_, err := IsMinimalSquare(nil, 3)
+		assert.Error(t, err)
+	})
+
+	t.Run("blob that doesn't fit according to the non-interactive default rules", func(t *testing.T) {
+		// a blob of three shares must start at a multiple of two
+		blobLen := uint32(appconsts.FirstSparseShareContentSize + 2*appconsts.ContinuationSparseShareContentSize)
+		square := []Share{
+			shareWithData(appconsts.TxNamespaceID, true, 1, []byte{1}),
+			shareWithData(nsOne, true, blobLen, nil),
+			shareWithData(nsOne, false, 0, nil),
+			shareWithData(nsOne, false, 0, nil),
+		}
+		_, err := IsMinimalSquare(square, 2)
+		assert.Error(t, err)
+	})
+}
+
+func TestValidateSquareSize(t *testing.T) {
+	type testCase struct {
+		squareSize int
+		wantErr    bool
+	}
+	testCases := []testCase{
+		{squareSize: 1},
+		{squareSize: 2},
+		{squareSize: MaxSquareSize},
+		{squareSize: 0, wantErr: true},
+		{squareSize: -4, wantErr: true},
+		{squareSize: 3, wantErr: true},
+		{squareSize: 2 * MaxSquareSize, wantErr: true},
+		{squareSize: 1 << 40, wantErr: true},
+		{squareSize: math.MinInt, wantErr: true},
+	}
+	for _, tc := range testCases {
+		err := ValidateSquareSize(tc.squareSize)
+		if tc.wantErr {
+			assert.Error(t, err, tc.squareSize)
+			continue
+		}
+		assert.NoError(t, err, tc.squareSize)
+	}
+}
+
+func TestSquareSizeAboveMaximumIsRejected(t *testing.T) {
+	squareSize := 2 * MaxSquareSize
+	_, err := BuildSquare(nil, nil, squareSize)
+	assert.Error(t, err)
+	_, err = ExtendSquare(nil, squareSize)
+	assert.Error(t, err)
+	_, err = ExtractODS(nil, 2*squareSize)
+	assert.Error(t, err)
+	_, err = SamplingCoordinates(nil, nsOne, squareSize)
+	assert.Error(t, err)
+	_, err = IsMinimalSquare(nil, squareSize)
+	assert.Error(t, err)
+	_, _, err = BlobRowSpan(0, 1, squareSize)
+	assert.Error(t, err)
+}
+
+func TestValidateCanonicalNamespaceOrder(t *testing.T) {
+	nsTwo := namespace.ID{2, 2, 2, 2, 2, 2, 2, 2}
+	txs := TxsToBytes(generateRandomTxs(2, 100))
+	blobs := []coretypes.Blob{
+		generateRandomBlobWithNamespace(nsOne, 100),
+		generateRandomBlobWithNamespace(nsOne, 1000),
+		generateRandomBlobWithNamespace(nsTwo, 100),
+	}
+	square, err := BuildSquare(txs, blobs, 4)
+	require.NoError(t, err)
+	require.NoError(t, ValidateCanonicalNamespaceOrder(square))
+
+	pfbWriter := NewCompactShareSplitter(appconsts.PayForBlobNamespaceID, appconsts.ShareVersionZero)
+	require.NoError(t, pfbWriter.WriteTx(coretypes.Tx{1, 2, 3}))
+	pfbShares, _, err := pfbWriter.Export(0)
+	require.NoError(t, err)
+	txShare := square[0]
+	blobOne := shareWithData(nsOne, true, 1, []byte{1})
+	blobTwo := shareWithData(nsTwo, true, 1, []byte{2})
+	reservedPadding, err := ReservedPaddingShare()
+	require.NoError(t, err)
+	namespacePadding, err := NamespacePaddingShare(nsOne)
+	require.NoError(t, err)
+	tailPadding, err := TailPaddingShare()
+	require.NoError(t, err)
+	parity, err := NewParityShare(blobOne.ToBytes())
+	require.NoError(t, err)
+
+	type testCase struct {
+		name    string
+		shares  []Share
+		wantErr bool
+	}
+	testCases := []testCase{
+		{name: "canonical order", shares: []Share{txShare, pfbShares[0], reservedPadding, blobOne, namespacePadding, blobTwo, tailPadding}},
+		{name: "pay for blob before tx", shares: []Share{pfbShares[0], txShare}, wantErr: true},
+		{name: "blob before reserved padding", shares: []Share{txShare, blobOne, reservedPadding}, wantErr: true},
+		{name: "blob namespaces out of order", shares: []Share{blobTwo, blobOne}, wantErr: true},
+		{name: "namespace padding after a greater namespace", shares: []Share{blobTwo, namespacePadding}, wantErr: true},
+		{name: "blob after tail padding", shares: []Share{blobOne, tailPadding, blobTwo}, wantErr: true},
+		{name: "parity share", shares: []Share{blobOne, parity}, wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateCanonicalNamespaceOrder(tc.shares)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package shares
+
+// ShareVersionHistogram returns the number of shares of each share version in
+// shares. Shares that are too short to contain an info byte and parity shares
+// (which don't contain an info byte) are not included in the histogram and are
+// instead counted in invalid.
+func ShareVersionHistogram(shares []Share) (histogram map[uint8]int, invalid int) {
+	histogram = make(map[uint8]int)
+	for _, share := range shares {
+		version, err := share.Version()
+		if err != nil {
+			invalid++
+			continue
+		}
+		histogram[version]++
+	}
+	return histogram, invalid
+}
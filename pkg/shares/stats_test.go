@@ -0,0 +1,37 @@
+package shares
+
+import (
+	"testing"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShareVersionHistogram(t *testing.T) {
+	versionZero := shareWithData(nsOne, true, 1, []byte{1})
+	// info byte with version 1 and the sequence start indicator set
+	versionOne := Share{data: []byte{1, 1, 1, 1, 1, 1, 1, 1, 3, 0, 0, 0, 0}}
+	tooShort := Share{data: []byte{1, 1, 1, 1, 1, 1, 1, 1}}
+	parity := Share{data: make([]byte, appconsts.ShareSize), isParity: true}
+
+	type testCase struct {
+		name          string
+		shares        []Share
+		wantHistogram map[uint8]int
+		wantInvalid   int
+	}
+	testCases := []testCase{
+		{name: "no shares", shares: nil, wantHistogram: map[uint8]int{}, wantInvalid: 0},
+		{name: "one version", shares: []Share{versionZero, versionZero}, wantHistogram: map[uint8]int{0: 2}, wantInvalid: 0},
+		{name: "two versions", shares: []Share{versionZero, versionOne, versionZero}, wantHistogram: map[uint8]int{0: 2, 1: 1}, wantInvalid: 0},
+		{name: "invalid shares", shares: []Share{versionZero, tooShort, parity}, wantHistogram: map[uint8]int{0: 1}, wantInvalid: 2},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			histogram, invalid := ShareVersionHistogram(tc.shares)
+			assert.Equal(t, tc.wantHistogram, histogram)
+			assert.Equal(t, tc.wantInvalid, invalid)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package shares
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+)
+
+// ShareAtOffset reads the share at index shareIndex from r. It assumes r
+// contains contiguous shares of appconsts.ShareSize bytes each so the share is
+// read from byte offset shareIndex * appconsts.ShareSize. It returns an error
+// if r doesn't contain a full share at that offset.
+func ShareAtOffset(r io.ReaderAt, shareIndex int) (*Share, error) {
+	if shareIndex < 0 {
+		return nil, fmt.Errorf("share index %d must be non-negative", shareIndex)
+	}
+	data := make([]byte, appconsts.ShareSize)
+	n, err := r.ReadAt(data, int64(shareIndex)*appconsts.ShareSize)
+	if n < appconsts.ShareSize {
+		if err != nil {
+			return nil, fmt.Errorf("failed to read share at index %d: read %d of %d bytes: %w", shareIndex, n, appconsts.ShareSize, err)
+		}
+		return nil, fmt.Errorf("short read of share at index %d: read %d of %d bytes", shareIndex, n, appconsts.ShareSize)
+	}
+	return newShare(data)
+}
+
+// FlattenShares concatenates the bytes of shares into one contiguous byte
+// slice of length len(shares) * appconsts.ShareSize.
+func FlattenShares(shares []Share) []byte {
+	flat := make([]byte, 0, len(shares)*appconsts.ShareSize)
+	for _, share := range shares {
+		flat = append(flat, share.data...)
+	}
+	return flat
+}
+
+// UnflattenShares splits data produced by FlattenShares back into shares. The
+// returned shares alias data. It returns an error if the length of data is not
+// a multiple of appconsts.ShareSize.
+func UnflattenShares(data []byte) ([]Share, error) {
+	if len(data)%appconsts.ShareSize != 0 {
+		return nil, fmt.Errorf("data length %d is not a multiple of share size %d", len(data), appconsts.ShareSize)
+	}
+	shares := make([]Share, len(data)/appconsts.ShareSize)
+	for i := range shares {
+		start := i * appconsts.ShareSize
+		end := start + appconsts.ShareSize
+		shares[i] = Share{data: data[start:end:end]}
+	}
+	return shares, nil
+}
@@ -0,0 +1,80 @@
+package shares
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShareAtOffset(t *testing.T) {
+	shares := []Share{
+		shareWithData(nsOne, true, 1, []byte{1}),
+		shareWithData(nsOne, true, 1, []byte{2}),
+		shareWithData(nsOne, true, 1, []byte{3}),
+	}
+	r := bytes.NewReader(bytes.Join(ToBytes(shares), nil))
+
+	for i, want := range shares {
+		got, err := ShareAtOffset(r, i)
+		require.NoError(t, err)
+		assert.Equal(t, want.ToBytes(), got.ToBytes())
+	}
+}
+
+func TestShareAtOffsetErrors(t *testing.T) {
+	share := shareWithData(nsOne, true, 1, []byte{1})
+	r := bytes.NewReader(share.ToBytes())
+
+	_, err := ShareAtOffset(r, -1)
+	assert.Error(t, err)
+
+	_, err = ShareAtOffset(r, 1)
+	assert.Error(t, err)
+
+	partial := bytes.NewReader(share.ToBytes()[:appconsts.ShareSize-1])
+	_, err = ShareAtOffset(partial, 0)
+	assert.Error(t, err)
+
+	_, err = ShareAtOffset(shortReaderAt{}, 0)
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "%!w")
+	assert.Contains(t, err.Error(), "short read")
+}
+
+// shortReaderAt is an io.ReaderAt that reads one byte without returning an
+// error.
+type shortReaderAt struct{}
+
+func (shortReaderAt) ReadAt(p []byte, _ int64) (int, error) {
+	return 1, nil
+}
+
+func TestFlattenShares(t *testing.T) {
+	shares := []Share{
+		shareWithData(nsOne, true, 1, []byte{1}),
+		shareWithData(nsOne, true, 1, []byte{2}),
+	}
+	flat := FlattenShares(shares)
+	assert.Len(t, flat, len(shares)*appconsts.ShareSize)
+
+	got, err := UnflattenShares(flat)
+	require.NoError(t, err)
+	assert.Equal(t, shares, got)
+}
+
+func TestFlattenSharesEmpty(t *testing.T) {
+	flat := FlattenShares(nil)
+	assert.Empty(t, flat)
+
+	got, err := UnflattenShares(flat)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestUnflattenSharesInvalidLength(t *testing.T) {
+	_, err := UnflattenShares(make([]byte, appconsts.ShareSize+1))
+	assert.Error(t, err)
+}
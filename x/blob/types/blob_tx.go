@@ -2,12 +2,15 @@ package types
 
 import (
 	"bytes"
+	"fmt"
+	"hash"
 
 	"github.com/celestiaorg/celestia-app/pkg/appconsts"
 	shares "github.com/celestiaorg/celestia-app/pkg/shares"
 	"github.com/celestiaorg/nmt/namespace"
 	"github.com/cosmos/cosmos-sdk/client"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	coretypes "github.com/tendermint/tendermint/types"
 )
 
 // Blob wraps the tendermint type so that users can simply import this one.
@@ -92,6 +95,114 @@ func ValidateBlobTx(txcfg client.TxEncodingConfig, bTx tmproto.BlobTx) error {
 	return nil
 }
 
+// MatchBlobsToPFBs parses the MsgPayForBlobs from the compact shares in the
+// PayForBlob namespace of squareShares and the blobs from the sparse shares of
+// squareShares, then matches each blob to the share commitment of a
+// MsgPayForBlobs that it satisfies. It returns a map from the index of each PFB
+// transaction in the PayForBlob namespace to its blobs in the order of the
+// share commitments in the MsgPayForBlobs. It returns an error if any blob
+// doesn't satisfy a share commitment or if any share commitment isn't
+// satisfied by a blob. hasher is the base hash function used to construct the
+// namespaced Merkle trees of the commitments (e.g. sha256.New()).
+func MatchBlobsToPFBs(txcfg client.TxEncodingConfig, squareShares []shares.Share, supportedShareVersions []uint8, hasher hash.Hash) (map[int][]coretypes.Blob, error) {
+	var pfbShares, sparseShares []shares.Share
+	for _, share := range squareShares {
+		if err := share.DoesSupportVersions(supportedShareVersions); err != nil {
+			return nil, err
+		}
+		switch {
+		case share.NamespaceID().Equal(appconsts.PayForBlobNamespaceID):
+			pfbShares = append(pfbShares, share)
+		case !share.IsCompactShare():
+			sparseShares = append(sparseShares, share)
+		}
+	}
+
+	rawPFBs, err := shares.ParseTxs(pfbShares)
+	if err != nil {
+		return nil, err
+	}
+	pfbs := make([]*MsgPayForBlobs, len(rawPFBs))
+	matched := make(map[int][]coretypes.Blob, len(rawPFBs))
+	for i, rawPFB := range rawPFBs {
+		tx := []byte(rawPFB)
+		if wrappedTx, isWrapped := coretypes.UnmarshalIndexWrapper(rawPFB); isWrapped {
+			tx = wrappedTx.Tx
+		}
+		sdkTx, err := txcfg.TxDecoder()(tx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode PFB tx %d: %w", i, err)
+		}
+		msgs := sdkTx.GetMsgs()
+		if len(msgs) != 1 {
+			return nil, fmt.Errorf("PFB tx %d: %w", i, ErrMultipleMsgsInBlobTx)
+		}
+		pfb, ok := msgs[0].(*MsgPayForBlobs)
+		if !ok {
+			return nil, fmt.Errorf("PFB tx %d: %w", i, ErrNoPFB)
+		}
+		pfbs[i] = pfb
+		matched[i] = make([]coretypes.Blob, len(pfb.ShareCommitments))
+	}
+
+	blobs, err := shares.ParseBlobs(sparseShares)
+	if err != nil {
+		return nil, err
+	}
+	// isMatched records which share commitments have been satisfied by a blob
+	isMatched := make([][]bool, len(pfbs))
+	for i, pfb := range pfbs {
+		isMatched[i] = make([]bool, len(pfb.ShareCommitments))
+	}
+	for blobIndex, blob := range blobs {
+		blobShares, err := shares.SplitBlobs(0, nil, []coretypes.Blob{blob}, false)
+		if err != nil {
+			return nil, err
+		}
+		subtreeWidth := shares.MinSquareSize(len(blobShares))
+
+		found := false
+		for i := 0; i < len(pfbs) && !found; i++ {
+			pfb := pfbs[i]
+			for j, commitment := range pfb.ShareCommitments {
+				if isMatched[i][j] || !pfbDescribesBlob(pfb, j, blob) {
+					continue
+				}
+				if shares.VerifyBlobCommitment(blobShares, commitment, subtreeWidth, hasher) != nil {
+					continue
+				}
+				matched[i][j] = blob
+				isMatched[i][j] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("blob %d in namespace %x does not satisfy the share commitment of any PFB", blobIndex, blob.NamespaceID)
+		}
+	}
+
+	for i := range isMatched {
+		for j, ok := range isMatched[i] {
+			if !ok {
+				return nil, fmt.Errorf("share commitment %d of PFB tx %d is not satisfied by any blob", j, i)
+			}
+		}
+	}
+	return matched, nil
+}
+
+// pfbDescribesBlob returns true if the namespace, size, and share version of
+// the blob at index i of pfb match blob.
+func pfbDescribesBlob(pfb *MsgPayForBlobs, i int, blob coretypes.Blob) bool {
+	if i >= len(pfb.NamespaceIds) || i >= len(pfb.BlobSizes) || i >= len(pfb.ShareVersions) {
+		return false
+	}
+	return bytes.Equal(pfb.NamespaceIds[i], blob.NamespaceID) &&
+		pfb.BlobSizes[i] == uint32(len(blob.Data)) &&
+		pfb.ShareVersions[i] == uint32(blob.ShareVersion)
+}
+
 func BlobTxSharesUsed(btx tmproto.BlobTx) int {
 	sharesUsed := 0
 	for _, blob := range btx.Blobs {
@@ -1,10 +1,12 @@
 package types
 
 import (
+	"crypto/sha256"
 	"testing"
 
 	"github.com/celestiaorg/celestia-app/app/encoding"
 	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/celestia-app/pkg/shares"
 	"github.com/celestiaorg/celestia-app/testutil/namespace"
 	tmrand "github.com/tendermint/tendermint/libs/rand"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
@@ -116,3 +118,52 @@ func randMsgPayForBlobsWithNamespaceAndSigner(t *testing.T, signer string, nid [
 	}
 	return msg, blob
 }
+
+func TestMatchBlobsToPFBs(t *testing.T) {
+	_, addr, signer, encCfg := setupSigTest(t)
+
+	// signedPFB returns a signed and index wrapped PFB for a random blob
+	signedPFB := func(size int) ([]byte, coretypes.Blob) {
+		msg, blob := randMsgPayForBlobsWithNamespaceAndSigner(t, addr.String(), namespace.RandomBlobNamespace(), size)
+		builder := signer.NewTxBuilder(SetGasLimit(10000000))
+		stx, err := signer.BuildSignedTx(builder, msg)
+		require.NoError(t, err)
+		rawTx, err := encCfg.TxConfig.TxEncoder()(stx)
+		require.NoError(t, err)
+		wrappedTx, err := coretypes.MarshalIndexWrapper(rawTx, 0)
+		require.NoError(t, err)
+		return wrappedTx, coretypes.Blob{NamespaceID: blob.NamespaceId, Data: blob.Data, ShareVersion: uint8(blob.ShareVersion)}
+	}
+	pfbOne, blobOne := signedPFB(100)
+	pfbTwo, blobTwo := signedPFB(1000)
+
+	t.Run("every blob matches a PFB", func(t *testing.T) {
+		square, err := shares.BuildSquare([][]byte{pfbOne, pfbTwo}, []coretypes.Blob{blobOne, blobTwo}, 8)
+		require.NoError(t, err)
+		got, err := MatchBlobsToPFBs(encCfg.TxConfig, square, appconsts.SupportedShareVersions, sha256.New())
+		require.NoError(t, err)
+		assert.Equal(t, map[int][]coretypes.Blob{0: {blobOne}, 1: {blobTwo}}, got)
+	})
+
+	t.Run("blob that doesn't match its PFB", func(t *testing.T) {
+		tampered := coretypes.Blob{NamespaceID: blobOne.NamespaceID, Data: tmrand.Bytes(len(blobOne.Data)), ShareVersion: blobOne.ShareVersion}
+		square, err := shares.BuildSquare([][]byte{pfbOne}, []coretypes.Blob{tampered}, 4)
+		require.NoError(t, err)
+		_, err = MatchBlobsToPFBs(encCfg.TxConfig, square, appconsts.SupportedShareVersions, sha256.New())
+		assert.Error(t, err)
+	})
+
+	t.Run("PFB without a blob", func(t *testing.T) {
+		square, err := shares.BuildSquare([][]byte{pfbOne, pfbTwo}, []coretypes.Blob{blobOne}, 8)
+		require.NoError(t, err)
+		_, err = MatchBlobsToPFBs(encCfg.TxConfig, square, appconsts.SupportedShareVersions, sha256.New())
+		assert.Error(t, err)
+	})
+
+	t.Run("unsupported share version", func(t *testing.T) {
+		square, err := shares.BuildSquare([][]byte{pfbOne}, []coretypes.Blob{blobOne}, 4)
+		require.NoError(t, err)
+		_, err = MatchBlobsToPFBs(encCfg.TxConfig, square, []uint8{1}, sha256.New())
+		assert.Error(t, err)
+	})
+}
@@ -6,7 +6,6 @@ import (
 
 	"github.com/celestiaorg/celestia-app/pkg/appconsts"
 	appshares "github.com/celestiaorg/celestia-app/pkg/shares"
-	"github.com/celestiaorg/nmt"
 	"github.com/celestiaorg/nmt/namespace"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/tendermint/tendermint/crypto/merkle"
@@ -148,38 +147,10 @@ func CreateCommitment(blob *Blob) ([]byte, error) {
 	// equal to the minimum square size the blob can be included in. See
 	// https://github.com/celestiaorg/celestia-app/blob/fbfbf111bcaa056e53b0bc54d327587dee11a945/docs/architecture/adr-008-blocksize-independent-commitment.md
 	minSquareSize := BlobMinSquareSize(len(blob.Data))
-	treeSizes, err := merkleMountainRangeSizes(uint64(len(shares)), uint64(minSquareSize))
+	subTreeRoots, err := appshares.SubtreeRoots(shares, minSquareSize, sha256.New())
 	if err != nil {
 		return nil, err
 	}
-	leafSets := make([][][]byte, len(treeSizes))
-	cursor := uint64(0)
-	for i, treeSize := range treeSizes {
-		leafSets[i] = appshares.ToBytes(shares[cursor : cursor+treeSize])
-		cursor = cursor + treeSize
-	}
-
-	// create the commitments by pushing each leaf set onto an nmt
-	subTreeRoots := make([][]byte, len(leafSets))
-	for i, set := range leafSets {
-		// create the nmt todo(evan) use nmt wrapper
-		tree := nmt.New(sha256.New())
-		for _, leaf := range set {
-			// the namespace must be added again here even though it is already
-			// included in the leaf to ensure that the hash will match that of
-			// the nmt wrapper (pkg/wrapper). Each namespace is added to keep
-			// the namespace in the share, and therefore the parity data, while
-			// also allowing for the manual addition of the parity namespace to
-			// the parity data.
-			nsLeaf := append(make([]byte, 0), append(blob.NamespaceId, leaf...)...)
-			err := tree.Push(nsLeaf)
-			if err != nil {
-				return nil, err
-			}
-		}
-		// add the root
-		subTreeRoots[i] = tree.Root()
-	}
 	return merkle.HashFromByteSlices(subTreeRoots), nil
 }
 
@@ -270,31 +241,3 @@ func BlobMinSquareSize[T constraints.Integer](blobSize T) T {
 	shareCount := appshares.SparseSharesNeeded(uint32(blobSize))
 	return T(appshares.MinSquareSize(shareCount))
 }
-
-// merkleMountainRangeSizes returns the sizes (number of leaf nodes) of the
-// trees in a merkle mountain range constructed for a given totalSize and
-// maxTreeSize.
-//
-// https://docs.grin.mw/wiki/chain-state/merkle-mountain-range/
-// https://github.com/opentimestamps/opentimestamps-server/blob/master/doc/merkle-mountain-range.md
-// TODO: potentially rename function because this doesn't return heights
-func merkleMountainRangeSizes(totalSize, maxTreeSize uint64) ([]uint64, error) {
-	var treeSizes []uint64
-
-	for totalSize != 0 {
-		switch {
-		case totalSize >= maxTreeSize:
-			treeSizes = append(treeSizes, maxTreeSize)
-			totalSize = totalSize - maxTreeSize
-		case totalSize < maxTreeSize:
-			treeSize, err := appshares.RoundDownPowerOfTwo(totalSize)
-			if err != nil {
-				return treeSizes, err
-			}
-			treeSizes = append(treeSizes, treeSize)
-			totalSize = totalSize - treeSize
-		}
-	}
-
-	return treeSizes, nil
-}
@@ -15,52 +15,6 @@ import (
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 )
 
-func Test_merkleMountainRangeHeights(t *testing.T) {
-	type test struct {
-		totalSize  uint64
-		squareSize uint64
-		expected   []uint64
-	}
-	tests := []test{
-		{
-			totalSize:  11,
-			squareSize: 4,
-			expected:   []uint64{4, 4, 2, 1},
-		},
-		{
-			totalSize:  2,
-			squareSize: 64,
-			expected:   []uint64{2},
-		},
-		{
-			totalSize:  64,
-			squareSize: 8,
-			expected:   []uint64{8, 8, 8, 8, 8, 8, 8, 8},
-		},
-		// Height
-		// 3              x                               x
-		//              /    \                         /    \
-		//             /      \                       /      \
-		//            /        \                     /        \
-		//           /          \                   /          \
-		// 2        x            x                 x            x
-		//        /   \        /   \             /   \        /   \
-		// 1     x     x      x     x           x     x      x     x         x
-		//      / \   / \    / \   / \         / \   / \    / \   / \      /   \
-		// 0   0   1 2   3  4   5 6   7       8   9 10  11 12 13 14  15   16   17    18
-		{
-			totalSize:  19,
-			squareSize: 8,
-			expected:   []uint64{8, 8, 2, 1},
-		},
-	}
-	for _, tt := range tests {
-		res, err := merkleMountainRangeSizes(tt.totalSize, tt.squareSize)
-		require.NoError(t, err)
-		assert.Equal(t, tt.expected, res)
-	}
-}
-
 // TestCreateCommitment only shows if something changed, it doesn't actually
 // show that the commitment bytes are being created correctly.
 // TODO: verify the commitment bytes